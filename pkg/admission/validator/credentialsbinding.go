@@ -9,6 +9,7 @@ import (
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/security"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -27,7 +28,8 @@ func NewCredentialsBindingValidator(mgr manager.Manager) extensionswebhook.Valid
 	}
 }
 
-// Validate checks whether the given CredentialsBinding refers to a Secret with a valid metal service account.
+// Validate checks whether the given CredentialsBinding refers to a Secret with a valid metal service account or to a
+// WorkloadIdentity with a valid metal configuration.
 func (cb *credentialsBinding) Validate(ctx context.Context, newObj, oldObj client.Object) error {
 	credentialsBinding, ok := newObj.(*security.CredentialsBinding)
 	if !ok {
@@ -57,6 +59,13 @@ func (cb *credentialsBinding) Validate(ctx context.Context, newObj, oldObj clien
 		}
 
 		return metalvalidation.ValidateCloudProviderSecret(secret)
+	case credentialsBinding.CredentialsRef.APIVersion == securityv1alpha1.SchemeGroupVersion.String() && credentialsBinding.CredentialsRef.Kind == "WorkloadIdentity":
+		workloadIdentity := &securityv1alpha1.WorkloadIdentity{}
+		if err := cb.apiReader.Get(ctx, credentialsKey, workloadIdentity); err != nil {
+			return err
+		}
+
+		return validateWorkloadIdentity(workloadIdentity)
 	default:
 		return fmt.Errorf("unsupported credentials reference: version %q, kind %q", credentialsBinding.CredentialsRef.APIVersion, credentialsBinding.CredentialsRef.Kind)
 	}
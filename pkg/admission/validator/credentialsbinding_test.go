@@ -10,12 +10,14 @@ import (
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/security"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
 	mockclient "github.com/gardener/gardener/third_party/mock/controller-runtime/client"
 	mockmanager "github.com/gardener/gardener/third_party/mock/controller-runtime/manager"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"go.uber.org/mock/gomock"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/admission/validator"
@@ -118,6 +120,58 @@ var _ = Describe("CredentialsBinding validator", func() {
 			Expect(credentialsBindingValidator.Validate(ctx, credentialsBinding, nil)).To(Succeed())
 		})
 
+		Context("WorkloadIdentity", func() {
+			BeforeEach(func() {
+				credentialsBinding.CredentialsRef.APIVersion = securityv1alpha1.SchemeGroupVersion.String()
+				credentialsBinding.CredentialsRef.Kind = "WorkloadIdentity"
+			})
+
+			expectWorkloadIdentity := func(targetType string, providerConfig []byte) {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&securityv1alpha1.WorkloadIdentity{})).
+					DoAndReturn(func(_ context.Context, _ client.ObjectKey, obj *securityv1alpha1.WorkloadIdentity, _ ...client.GetOption) error {
+						obj.Spec.TargetSystem.Type = targetType
+						if providerConfig != nil {
+							obj.Spec.TargetSystem.ProviderConfig = &runtime.RawExtension{Raw: providerConfig}
+						}
+						return nil
+					})
+			}
+
+			It("should return err if it fails to get the corresponding WorkloadIdentity", func() {
+				apiReader.EXPECT().Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, gomock.AssignableToTypeOf(&securityv1alpha1.WorkloadIdentity{})).Return(fakeErr)
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBinding, nil)
+				Expect(err).To(MatchError(fakeErr))
+			})
+
+			It("should return err when the WorkloadIdentity targets another provider", func() {
+				expectWorkloadIdentity("other", nil)
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBinding, nil)
+				Expect(err).To(MatchError(`unsupported target system type "other" of WorkloadIdentity, expected "ironcore-metal"`))
+			})
+
+			It("should return err when the WorkloadIdentity has no provider config", func() {
+				expectWorkloadIdentity("ironcore-metal", nil)
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBinding, nil)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should return err when the provider config of the WorkloadIdentity is not valid", func() {
+				expectWorkloadIdentity("ironcore-metal", []byte(`{"apiVersion":"ironcore-metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkloadIdentityConfig"}`))
+
+				err := credentialsBindingValidator.Validate(ctx, credentialsBinding, nil)
+				Expect(err).To(MatchError(ContainSubstring("spec.targetSystem.providerConfig.namespace")))
+			})
+
+			It("should succeed when the WorkloadIdentity is valid", func() {
+				expectWorkloadIdentity("ironcore-metal", []byte(`{"apiVersion":"ironcore-metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkloadIdentityConfig","namespace":"default"}`))
+
+				Expect(credentialsBindingValidator.Validate(ctx, credentialsBinding, nil)).To(Succeed())
+			})
+		})
+
 		It("should return nil when the CredentialsBinding did not change", func() {
 			old := credentialsBinding.DeepCopy()
 
@@ -88,7 +88,7 @@ var _ = Describe("SecretBinding validator", func() {
 				})
 
 			err := secretBindingValidator.Validate(context.TODO(), secretBinding, nil)
-			Expect(err).To(MatchError("missing field: token in cloud provider secret"))
+			Expect(err).To(MatchError(ContainSubstring("missing credentials in cloud provider secret")))
 		})
 
 		It("should return nil when the corresponding Secret is valid", func() {
@@ -5,12 +5,15 @@ package validator
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	"github.com/gardener/gardener/pkg/apis/core"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -19,11 +22,13 @@ import (
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/admission"
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 	metalvalidation "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/validation"
 )
 
 type shoot struct {
 	client         client.Client
+	apiReader      client.Reader
 	decoder        runtime.Decoder
 	lenientDecoder runtime.Decoder
 }
@@ -31,7 +36,10 @@ type shoot struct {
 // NewShootValidator returns a new instance of a shoot validator.
 func NewShootValidator(mgr manager.Manager) extensionswebhook.Validator {
 	return &shoot{
-		decoder: serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		client:         mgr.GetClient(),
+		apiReader:      mgr.GetAPIReader(),
+		decoder:        serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		lenientDecoder: serializer.NewCodecFactory(mgr.GetScheme()).UniversalDecoder(),
 	}
 }
 
@@ -69,6 +77,7 @@ type validationContext struct {
 	infrastructureConfig *apismetal.InfrastructureConfig
 	controlPlaneConfig   *apismetal.ControlPlaneConfig
 	cloudProfile         *gardencorev1beta1.CloudProfile
+	cloudProfileConfig   *apismetal.CloudProfileConfig
 }
 
 func (s *shoot) validateContext(valContext *validationContext) field.ErrorList {
@@ -78,8 +87,11 @@ func (s *shoot) validateContext(valContext *validationContext) field.ErrorList {
 
 	allErrors = append(allErrors, metalvalidation.ValidateNetworking(valContext.shoot.Spec.Networking, networkPath)...)
 	allErrors = append(allErrors, metalvalidation.ValidateInfrastructureConfig(valContext.infrastructureConfig, valContext.shoot.Spec.Networking.Nodes, valContext.shoot.Spec.Networking.Pods, valContext.shoot.Spec.Networking.Services, infrastructureConfigPath)...)
+	allErrors = append(allErrors, metalvalidation.ValidateIPFamilies(valContext.shoot.Spec.Networking, valContext.infrastructureConfig, valContext.controlPlaneConfig, infrastructureConfigPath, controlPlaneConfigPath)...)
 	allErrors = append(allErrors, metalvalidation.ValidateWorkers(valContext.shoot.Spec.Provider.Workers, workersPath, &valContext.shoot.Spec)...)
+	allErrors = append(allErrors, metalvalidation.ValidateWorkerVolumes(valContext.shoot.Spec.Provider.Workers, valContext.cloudProfileConfig, workersPath)...)
 	allErrors = append(allErrors, metalvalidation.ValidateControlPlaneConfig(valContext.controlPlaneConfig, valContext.shoot.Spec.Kubernetes.Version, controlPlaneConfigPath)...)
+	allErrors = append(allErrors, metalvalidation.ValidateStorageConfig(valContext.controlPlaneConfig.Storage, valContext.cloudProfileConfig.StorageClasses, controlPlaneConfigPath.Child("storage"))...)
 
 	return allErrors
 }
@@ -90,7 +102,15 @@ func (s *shoot) validateCreate(ctx context.Context, shoot *core.Shoot) error {
 		return err
 	}
 
-	return s.validateContext(validationContext).ToAggregate()
+	allErrors := s.validateContext(validationContext)
+
+	ignitionSecretErrors, err := s.validateIgnitionSecrets(ctx, shoot)
+	if err != nil {
+		return err
+	}
+	allErrors = append(allErrors, ignitionSecretErrors...)
+
+	return allErrors.ToAggregate()
 }
 
 func (s *shoot) validateUpdate(ctx context.Context, oldShoot, currentShoot *core.Shoot) error {
@@ -121,10 +141,68 @@ func (s *shoot) validateUpdate(ctx context.Context, oldShoot, currentShoot *core
 	allErrors = append(allErrors, metalvalidation.ValidateWorkersUpdate(oldValContext.shoot.Spec.Provider.Workers, currentValContext.shoot.Spec.Provider.Workers, workersPath)...)
 	allErrors = append(allErrors, s.validateContext(currentValContext)...)
 
+	ignitionSecretErrors, err := s.validateIgnitionSecrets(ctx, currentShoot)
+	if err != nil {
+		return err
+	}
+	allErrors = append(allErrors, ignitionSecretErrors...)
+
 	return allErrors.ToAggregate()
 
 }
 
+// validateIgnitionSecrets validates the content of the secrets referenced by the extra ignition configs of the
+// workers which are not in the default ignition format, so that translation errors are reported on admission.
+func (s *shoot) validateIgnitionSecrets(ctx context.Context, shoot *core.Shoot) (field.ErrorList, error) {
+	allErrors := field.ErrorList{}
+
+	for i, worker := range shoot.Spec.Provider.Workers {
+		if worker.ProviderConfig == nil {
+			continue
+		}
+
+		workerConfig := &metalv1alpha1.WorkerConfig{}
+		if err := json.Unmarshal(worker.ProviderConfig.Raw, workerConfig); err != nil {
+			// reported by the worker validation
+			continue
+		}
+		if workerConfig.ExtraIgnition == nil || workerConfig.ExtraIgnition.Format != metalv1alpha1.IgnitionFormatButane {
+			continue
+		}
+
+		extraIgnitionPath := workersPath.Index(i).Child("providerConfig", "extraIgnition")
+		secretRefs := map[string]*field.Path{}
+		if workerConfig.ExtraIgnition.SecretRef != "" {
+			secretRefs[workerConfig.ExtraIgnition.SecretRef] = extraIgnitionPath.Child("secretRef")
+		}
+		for j, secretRef := range workerConfig.ExtraIgnition.SecretRefs {
+			secretRefs[secretRef] = extraIgnitionPath.Child("secretRefs").Index(j)
+		}
+
+		for _, resource := range shoot.Spec.Resources {
+			fldPath, ok := secretRefs[resource.Name]
+			if !ok || resource.ResourceRef.Kind != "Secret" {
+				continue
+			}
+
+			secret := &corev1.Secret{}
+			// Explicitly use the client.Reader to prevent controller-runtime to start Informer for Secrets
+			// under the hood. The latter increases the memory usage of the component.
+			if err := s.apiReader.Get(ctx, client.ObjectKey{Namespace: shoot.Namespace, Name: resource.ResourceRef.Name}, secret); err != nil {
+				if apierrors.IsNotFound(err) {
+					// the existence of referenced resources is validated by Gardener
+					continue
+				}
+				return nil, err
+			}
+
+			allErrors = append(allErrors, metalvalidation.ValidateIgnitionSecret(secret, workerConfig.ExtraIgnition.Format, fldPath)...)
+		}
+	}
+
+	return allErrors, nil
+}
+
 func newValidationContext(ctx context.Context, decoder runtime.Decoder, c client.Client, shoot *core.Shoot) (*validationContext, error) {
 	if shoot.Spec.Provider.InfrastructureConfig == nil {
 		return nil, field.Required(infrastructureConfigPath, "infrastructureConfig must be set for metal shoots")
@@ -150,11 +228,16 @@ func newValidationContext(ctx context.Context, decoder runtime.Decoder, c client
 	if cloudProfile.Spec.ProviderConfig == nil {
 		return nil, fmt.Errorf("providerConfig is not given for cloud profile %q", cloudProfile.Name)
 	}
+	cloudProfileConfig, err := decodeCloudProfileConfig(decoder, cloudProfile.Spec.ProviderConfig)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred while reading the cloud profile %q: %v", cloudProfile.Name, err)
+	}
 
 	return &validationContext{
 		shoot:                shoot,
 		infrastructureConfig: infrastructureConfig,
 		controlPlaneConfig:   controlPlaneConfig,
 		cloudProfile:         cloudProfile,
+		cloudProfileConfig:   cloudProfileConfig,
 	}, nil
 }
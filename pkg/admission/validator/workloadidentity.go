@@ -0,0 +1,80 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validator
+
+import (
+	"context"
+	"fmt"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	metalvalidation "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/validation"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var providerConfigPath = field.NewPath("spec", "targetSystem", "providerConfig")
+
+type workloadIdentity struct{}
+
+// NewWorkloadIdentityValidator returns a new instance of a WorkloadIdentity validator.
+func NewWorkloadIdentityValidator() extensionswebhook.Validator {
+	return &workloadIdentity{}
+}
+
+// Validate checks whether the given WorkloadIdentity contains a valid metal configuration.
+func (wi *workloadIdentity) Validate(_ context.Context, newObj, oldObj client.Object) error {
+	workloadIdentity, ok := newObj.(*securityv1alpha1.WorkloadIdentity)
+	if !ok {
+		return fmt.Errorf("wrong object type %T", newObj)
+	}
+	if workloadIdentity.Spec.TargetSystem.Type != metal.Type {
+		return nil
+	}
+
+	config, err := workloadIdentityConfig(workloadIdentity)
+	if err != nil {
+		return err
+	}
+
+	if oldObj != nil {
+		oldWorkloadIdentity, ok := oldObj.(*securityv1alpha1.WorkloadIdentity)
+		if !ok {
+			return fmt.Errorf("wrong object type %T for old object", oldObj)
+		}
+		if oldWorkloadIdentity.Spec.TargetSystem.Type == metal.Type {
+			oldConfig, err := workloadIdentityConfig(oldWorkloadIdentity)
+			if err == nil {
+				return metalvalidation.ValidateWorkloadIdentityConfigUpdate(oldConfig, config, providerConfigPath).ToAggregate()
+			}
+		}
+	}
+
+	return metalvalidation.ValidateWorkloadIdentityConfig(config, providerConfigPath).ToAggregate()
+}
+
+// validateWorkloadIdentity checks whether the given WorkloadIdentity targets metal and contains a valid configuration.
+func validateWorkloadIdentity(workloadIdentity *securityv1alpha1.WorkloadIdentity) error {
+	if workloadIdentity.Spec.TargetSystem.Type != metal.Type {
+		return fmt.Errorf("unsupported target system type %q of WorkloadIdentity, expected %q", workloadIdentity.Spec.TargetSystem.Type, metal.Type)
+	}
+
+	config, err := workloadIdentityConfig(workloadIdentity)
+	if err != nil {
+		return err
+	}
+	return metalvalidation.ValidateWorkloadIdentityConfig(config, providerConfigPath).ToAggregate()
+}
+
+func workloadIdentityConfig(workloadIdentity *securityv1alpha1.WorkloadIdentity) (*apismetal.WorkloadIdentityConfig, error) {
+	providerConfig := workloadIdentity.Spec.TargetSystem.ProviderConfig
+	if providerConfig == nil || len(providerConfig.Raw) == 0 {
+		return nil, field.Required(providerConfigPath, "the metal configuration of the WorkloadIdentity must be set")
+	}
+	return helper.WorkloadIdentityConfigFromBytes(providerConfig.Raw)
+}
@@ -0,0 +1,88 @@
+// SPDX-FileCopyrightText: 2025 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validator_test
+
+import (
+	"context"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	securityv1alpha1 "github.com/gardener/gardener/pkg/apis/security/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/admission/validator"
+)
+
+var _ = Describe("WorkloadIdentity validator", func() {
+	Describe("#Validate", func() {
+		var (
+			workloadIdentityValidator extensionswebhook.Validator
+
+			ctx              = context.TODO()
+			workloadIdentity *securityv1alpha1.WorkloadIdentity
+		)
+
+		providerConfig := func(namespace string) *runtime.RawExtension {
+			return &runtime.RawExtension{Raw: []byte(`{"apiVersion":"ironcore-metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkloadIdentityConfig","namespace":"` + namespace + `"}`)}
+		}
+
+		BeforeEach(func() {
+			workloadIdentityValidator = validator.NewWorkloadIdentityValidator()
+
+			workloadIdentity = &securityv1alpha1.WorkloadIdentity{
+				Spec: securityv1alpha1.WorkloadIdentitySpec{
+					TargetSystem: securityv1alpha1.TargetSystem{
+						Type:           "ironcore-metal",
+						ProviderConfig: providerConfig("default"),
+					},
+				},
+			}
+		})
+
+		It("should return err when obj is not a WorkloadIdentity", func() {
+			err := workloadIdentityValidator.Validate(ctx, &corev1.Secret{}, nil)
+			Expect(err).To(MatchError("wrong object type *v1.Secret"))
+		})
+
+		It("should ignore WorkloadIdentities of other target systems", func() {
+			workloadIdentity.Spec.TargetSystem.Type = "other"
+			workloadIdentity.Spec.TargetSystem.ProviderConfig = nil
+
+			Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)).To(Succeed())
+		})
+
+		It("should return err when the provider config is missing", func() {
+			workloadIdentity.Spec.TargetSystem.ProviderConfig = nil
+
+			err := workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)
+			Expect(err).To(MatchError(ContainSubstring("spec.targetSystem.providerConfig: Required value")))
+		})
+
+		It("should return err when the provider config cannot be decoded", func() {
+			workloadIdentity.Spec.TargetSystem.ProviderConfig = &runtime.RawExtension{Raw: []byte(`{"apiVersion":"ironcore-metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkloadIdentityConfig","unknown":"field"}`)}
+
+			Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)).NotTo(Succeed())
+		})
+
+		It("should succeed for a valid WorkloadIdentity", func() {
+			Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, nil)).To(Succeed())
+		})
+
+		It("should return err when the namespace is changed", func() {
+			old := workloadIdentity.DeepCopy()
+			workloadIdentity.Spec.TargetSystem.ProviderConfig = providerConfig("other")
+
+			err := workloadIdentityValidator.Validate(ctx, workloadIdentity, old)
+			Expect(err).To(MatchError(ContainSubstring("spec.targetSystem.providerConfig.namespace: Invalid value")))
+		})
+
+		It("should succeed when the WorkloadIdentity did not change", func() {
+			old := workloadIdentity.DeepCopy()
+
+			Expect(workloadIdentityValidator.Validate(ctx, workloadIdentity, old)).To(Succeed())
+		})
+	})
+})
@@ -4,6 +4,7 @@
 package loader
 
 import (
+	"fmt"
 	"os"
 
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config/install"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config/validation"
 )
 
 var (
@@ -57,5 +59,10 @@ func Load(data []byte) (*config.ControllerConfiguration, error) {
 		return nil, err
 	}
 
-	return decoded.(*config.ControllerConfiguration), nil
+	cfg = decoded.(*config.ControllerConfiguration)
+	if errs := validation.ValidateControllerConfiguration(cfg); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid controller configuration: %w", errs.ToAggregate())
+	}
+
+	return cfg, nil
 }
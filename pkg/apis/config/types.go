@@ -5,6 +5,8 @@ package config
 
 import (
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -43,6 +45,19 @@ type ETCDStorage struct {
 	ClassName *string
 	// Capacity is the storage capacity used in etcd-main volume claims.
 	Capacity *resource.Quantity
+	// Provisioner is the provisioner of the storage class used in etcd-main volume claims.
+	Provisioner *string
+	// Parameters are the parameters of the storage class used in etcd-main volume claims.
+	Parameters map[string]string
+	// ReclaimPolicy is the reclaim policy of the storage class used in etcd-main volume claims.
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy
+	// VolumeBindingMode is the volume binding mode of the storage class used in etcd-main volume claims.
+	VolumeBindingMode *storagev1.VolumeBindingMode
+	// AllowVolumeExpansion specifies whether volumes of the storage class used in etcd-main volume claims can be expanded.
+	AllowVolumeExpansion *bool
+	// AllowedTopologies restricts the topology domains in which volumes of the storage class used in etcd-main
+	// volume claims can be provisioned.
+	AllowedTopologies []corev1.TopologySelectorTerm
 }
 
 // ETCDBackup is an etcd backup configuration.
@@ -5,6 +5,8 @@ package v1alpha1
 
 import (
 	healthcheckconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	componentbaseconfigv1alpha1 "k8s.io/component-base/config/v1alpha1"
@@ -49,6 +51,25 @@ type ETCDStorage struct {
 	// Capacity is the storage capacity used in etcd-main volume claims.
 	// +optional
 	Capacity *resource.Quantity `json:"capacity,omitempty"`
+	// Provisioner is the provisioner of the storage class used in etcd-main volume claims.
+	// +optional
+	Provisioner *string `json:"provisioner,omitempty"`
+	// Parameters are the parameters of the storage class used in etcd-main volume claims.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// ReclaimPolicy is the reclaim policy of the storage class used in etcd-main volume claims.
+	// +optional
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+	// VolumeBindingMode is the volume binding mode of the storage class used in etcd-main volume claims.
+	// +optional
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty"`
+	// AllowVolumeExpansion specifies whether volumes of the storage class used in etcd-main volume claims can be expanded.
+	// +optional
+	AllowVolumeExpansion *bool `json:"allowVolumeExpansion,omitempty"`
+	// AllowedTopologies restricts the topology domains in which volumes of the storage class used in etcd-main
+	// volume claims can be provisioned.
+	// +optional
+	AllowedTopologies []corev1.TopologySelectorTerm `json:"allowedTopologies,omitempty"`
 }
 
 // ETCDBackup is an etcd backup configuration.
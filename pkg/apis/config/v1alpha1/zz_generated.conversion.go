@@ -14,6 +14,8 @@ import (
 
 	apisconfigv1alpha1 "github.com/gardener/gardener/extensions/pkg/apis/config/v1alpha1"
 	config "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
@@ -153,6 +155,12 @@ func Convert_config_ETCDBackup_To_v1alpha1_ETCDBackup(in *config.ETCDBackup, out
 func autoConvert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in *ETCDStorage, out *config.ETCDStorage, s conversion.Scope) error {
 	out.ClassName = (*string)(unsafe.Pointer(in.ClassName))
 	out.Capacity = (*resource.Quantity)(unsafe.Pointer(in.Capacity))
+	out.Provisioner = (*string)(unsafe.Pointer(in.Provisioner))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	out.ReclaimPolicy = (*v1.PersistentVolumeReclaimPolicy)(unsafe.Pointer(in.ReclaimPolicy))
+	out.VolumeBindingMode = (*storagev1.VolumeBindingMode)(unsafe.Pointer(in.VolumeBindingMode))
+	out.AllowVolumeExpansion = (*bool)(unsafe.Pointer(in.AllowVolumeExpansion))
+	out.AllowedTopologies = *(*[]v1.TopologySelectorTerm)(unsafe.Pointer(&in.AllowedTopologies))
 	return nil
 }
 
@@ -164,6 +172,12 @@ func Convert_v1alpha1_ETCDStorage_To_config_ETCDStorage(in *ETCDStorage, out *co
 func autoConvert_config_ETCDStorage_To_v1alpha1_ETCDStorage(in *config.ETCDStorage, out *ETCDStorage, s conversion.Scope) error {
 	out.ClassName = (*string)(unsafe.Pointer(in.ClassName))
 	out.Capacity = (*resource.Quantity)(unsafe.Pointer(in.Capacity))
+	out.Provisioner = (*string)(unsafe.Pointer(in.Provisioner))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	out.ReclaimPolicy = (*v1.PersistentVolumeReclaimPolicy)(unsafe.Pointer(in.ReclaimPolicy))
+	out.VolumeBindingMode = (*storagev1.VolumeBindingMode)(unsafe.Pointer(in.VolumeBindingMode))
+	out.AllowVolumeExpansion = (*bool)(unsafe.Pointer(in.AllowVolumeExpansion))
+	out.AllowedTopologies = *(*[]v1.TopologySelectorTerm)(unsafe.Pointer(&in.AllowedTopologies))
 	return nil
 }
 
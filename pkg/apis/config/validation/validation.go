@@ -0,0 +1,68 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	metalvalidation "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/validation"
+)
+
+// ValidateControllerConfiguration validates a ControllerConfiguration object.
+func ValidateControllerConfiguration(cfg *config.ControllerConfiguration) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateETCDStorage(cfg.ETCD.Storage, field.NewPath("etcd", "storage"))...)
+
+	return allErrs
+}
+
+func validateETCDStorage(storage config.ETCDStorage, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if storage.ClassName != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(*storage.ClassName) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("className"), *storage.ClassName, msg))
+		}
+	}
+
+	if storage.Capacity != nil && storage.Capacity.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("capacity"), storage.Capacity.String(), "must be greater than zero"))
+	}
+
+	if storage.Provisioner != nil {
+		for _, msg := range validation.IsQualifiedName(*storage.Provisioner) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("provisioner"), *storage.Provisioner, msg))
+		}
+	}
+
+	for key := range storage.Parameters {
+		if len(key) == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("parameters"), key, "parameter keys must not be empty"))
+		}
+	}
+
+	allErrs = append(allErrs, metalvalidation.ValidateStorageClassModes(ptr.Deref(storage.Provisioner, ""), storage.ReclaimPolicy, storage.VolumeBindingMode, fldPath)...)
+
+	for i, term := range storage.AllowedTopologies {
+		termPath := fldPath.Child("allowedTopologies").Index(i)
+		if len(term.MatchLabelExpressions) == 0 {
+			allErrs = append(allErrs, field.Required(termPath.Child("matchLabelExpressions"), "must provide at least one label expression"))
+		}
+		for j, expr := range term.MatchLabelExpressions {
+			exprPath := termPath.Child("matchLabelExpressions").Index(j)
+			for _, msg := range validation.IsQualifiedName(expr.Key) {
+				allErrs = append(allErrs, field.Invalid(exprPath.Child("key"), expr.Key, msg))
+			}
+			if len(expr.Values) == 0 {
+				allErrs = append(allErrs, field.Required(exprPath.Child("values"), "must provide at least one value"))
+			}
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,107 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config"
+	. "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/config/validation"
+)
+
+var _ = Describe("ControllerConfiguration validation", func() {
+	var cfg *config.ControllerConfiguration
+
+	BeforeEach(func() {
+		cfg = &config.ControllerConfiguration{
+			ETCD: config.ETCD{
+				Storage: config.ETCDStorage{
+					ClassName:         ptr.To("gardener.cloud-fast"),
+					Capacity:          ptr.To(resource.MustParse("25Gi")),
+					Provisioner:       ptr.To("rbd.csi.ceph.com"),
+					Parameters:        map[string]string{"pool": "etcd"},
+					ReclaimPolicy:     ptr.To(corev1.PersistentVolumeReclaimDelete),
+					VolumeBindingMode: ptr.To(storagev1.VolumeBindingImmediate),
+					AllowedTopologies: []corev1.TopologySelectorTerm{
+						{
+							MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{
+								{Key: "topology.kubernetes.io/zone", Values: []string{"zone-a"}},
+							},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("#ValidateControllerConfiguration", func() {
+		It("should return no errors for a valid configuration", func() {
+			Expect(ValidateControllerConfiguration(cfg)).To(BeEmpty())
+		})
+
+		It("should return no errors for an empty configuration", func() {
+			Expect(ValidateControllerConfiguration(&config.ControllerConfiguration{})).To(BeEmpty())
+		})
+
+		It("should forbid an invalid etcd storage configuration", func() {
+			cfg.ETCD.Storage.ClassName = ptr.To("Fast_Class")
+			cfg.ETCD.Storage.Capacity = ptr.To(resource.MustParse("0"))
+			cfg.ETCD.Storage.ReclaimPolicy = ptr.To(corev1.PersistentVolumeReclaimRecycle)
+			cfg.ETCD.Storage.VolumeBindingMode = ptr.To(storagev1.VolumeBindingMode("foo"))
+			cfg.ETCD.Storage.AllowedTopologies = []corev1.TopologySelectorTerm{
+				{},
+				{
+					MatchLabelExpressions: []corev1.TopologySelectorLabelRequirement{
+						{Key: "topology.kubernetes.io/zone"},
+					},
+				},
+			}
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("etcd.storage.className"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("etcd.storage.capacity"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("etcd.storage.reclaimPolicy"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("etcd.storage.volumeBindingMode"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("etcd.storage.allowedTopologies[0].matchLabelExpressions"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("etcd.storage.allowedTopologies[1].matchLabelExpressions[0].values"),
+				})),
+			))
+		})
+
+		It("should require the volume binding mode WaitForFirstConsumer for local volumes", func() {
+			cfg.ETCD.Storage.Provisioner = ptr.To("kubernetes.io/no-provisioner")
+
+			Expect(ValidateControllerConfiguration(cfg)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("etcd.storage.volumeBindingMode"),
+				})),
+			))
+		})
+	})
+})
@@ -48,6 +48,18 @@ func InfrastructureConfigFromInfrastructure(infra *extensionsv1alpha1.Infrastruc
 	return &api.InfrastructureConfig{}, nil
 }
 
+// InfrastructureConfigFromCluster extracts the InfrastructureConfig from the
+// ProviderConfig section of the Shoot of the given cluster.
+func InfrastructureConfigFromCluster(cluster *controller.Cluster) (*api.InfrastructureConfig, error) {
+	config := &api.InfrastructureConfig{}
+	if cluster != nil && cluster.Shoot != nil && cluster.Shoot.Spec.Provider.InfrastructureConfig != nil && cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw != nil {
+		if _, _, err := lenientDecoder.Decode(cluster.Shoot.Spec.Provider.InfrastructureConfig.Raw, nil, config); err != nil {
+			return nil, fmt.Errorf("could not decode infrastructureConfig of shoot: %w", err)
+		}
+	}
+	return config, nil
+}
+
 // InfrastructureStatusFromRaw extracts the InfrastructureStatus from the
 // ProviderStatus section of the given Infrastructure.
 func InfrastructureStatusFromRaw(raw *runtime.RawExtension) (*api.InfrastructureStatus, error) {
@@ -72,3 +84,12 @@ func CloudProfileConfigFromCluster(cluster *controller.Cluster) (*api.CloudProfi
 	}
 	return cloudProfileConfig, nil
 }
+
+// WorkloadIdentityConfigFromBytes decodes the provider specific configuration of a WorkloadIdentity.
+func WorkloadIdentityConfigFromBytes(config []byte) (*api.WorkloadIdentityConfig, error) {
+	workloadIdentityConfig := &api.WorkloadIdentityConfig{}
+	if _, _, err := decoder.Decode(config, nil, workloadIdentityConfig); err != nil {
+		return nil, fmt.Errorf("could not decode workload identity config: %w", err)
+	}
+	return workloadIdentityConfig, nil
+}
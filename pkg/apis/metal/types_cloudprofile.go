@@ -4,6 +4,9 @@
 package metal
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,11 +23,43 @@ type CloudProfileConfig struct {
 	// RegionConfigs is the list of supported regions.
 	RegionConfigs []RegionConfig
 	MachineTypes  []MachineType
+	// StorageClasses defines the node-local StorageClasses which can be deployed into shoots.
+	StorageClasses StorageClasses
 }
 
 type MachineType struct {
 	Name         string
 	ServerLabels map[string]string
+	// Volumes are the volumes offered by the servers of this machine type. If set, the volume of a worker pool
+	// using this machine type must be satisfied by one of them.
+	Volumes []MachineTypeVolume
+	// Capacity is the capacity of the servers of this machine type. If set, the resources reserved for the kubelet
+	// and the system as well as the CPU and topology manager policies of the nodes are derived from it.
+	Capacity *MachineTypeCapacity
+	// Priority is the priority of this machine type for the priority expander of the cluster-autoscaler. Worker
+	// pools of machine types with higher priorities are preferred on scale-up, e.g. cheap or abundant servers.
+	Priority *int32
+}
+
+// MachineTypeCapacity is the capacity of the servers of a machine type.
+type MachineTypeCapacity struct {
+	// CPU is the number of CPUs of the servers.
+	CPU resource.Quantity
+	// Memory is the memory of the servers.
+	Memory resource.Quantity
+	// HugePages maps huge page sizes to the amount of memory of the servers which is preallocated as huge pages
+	// of that size.
+	HugePages map[string]resource.Quantity
+}
+
+// MachineTypeVolume is a volume offered by the servers of a machine type.
+type MachineTypeVolume struct {
+	// Type is the volume type, e.g. the disk technology.
+	Type string
+	// Size is the capacity of the volume.
+	Size resource.Quantity
+	// ServerLabels are additional labels selecting servers which offer this volume.
+	ServerLabels map[string]string
 }
 
 // MachineImages is a mapping from logical names and versions to provider-specific identifiers.
@@ -41,8 +76,64 @@ type RegionConfig struct {
 	Name string
 	// Server is the server endpoint of this region.
 	Server string
+	// FailoverServers is an ordered list of additional server endpoints of this region. The clients of the extension
+	// fail over to them if the server endpoints before them are unavailable.
+	FailoverServers []string
 	// CertificateAuthorityData is the CA data of the region server.
 	CertificateAuthorityData []byte
+	// ProxyURL is the URL of the proxy through which the region server is reached, e.g. an HTTP CONNECT proxy.
+	ProxyURL string
+	// TLSServerName is the name used to verify the serving certificate of the region server, e.g. if the server is
+	// reached through an egress gateway under a different name.
+	TLSServerName string
+	// AdditionalCertificateAuthorityData is a bundle of PEM encoded CA certificates which are trusted in addition to
+	// CertificateAuthorityData, e.g. the CA of an egress gateway. It requires CertificateAuthorityData to be set.
+	AdditionalCertificateAuthorityData []byte
+	// EgressCIDRs are the CIDRs under which the region server, or its proxy if ProxyURL is set, is reachable from the
+	// seeds. They are required for the egress network policy of the control plane components if the server or the
+	// proxy is addressed by a host name.
+	EgressCIDRs []string
+	// Node contains settings of the operating system of all nodes in this region.
+	Node *RegionNodeConfig
+}
+
+// RegionNodeConfig contains settings of the operating system of the nodes in a region.
+type RegionNodeConfig struct {
+	// NTPServers are the NTP servers the nodes synchronize their clocks with.
+	NTPServers []string
+	// DNSServers are the IP addresses of the DNS resolvers of the nodes.
+	DNSServers []string
+	// LLDP enables the LLDP daemon on the nodes.
+	LLDP bool
+	// CABundle is a bundle of PEM encoded certificates which are additionally trusted by the nodes, e.g. the CA of
+	// an internal registry.
+	CABundle string
+}
+
+// StorageClasses is a definition of a default and additional StorageClasses.
+type StorageClasses struct {
+	// Default is the default StorageClass.
+	Default *StorageClass
+	// Additional is a list of additional StorageClasses.
+	Additional []StorageClass
+}
+
+// StorageClass is a definition of a node-local StorageClass.
+type StorageClass struct {
+	// Name is the name of the StorageClass.
+	Name string
+	// Type is the volume type backing the StorageClass. It corresponds to the `volume.type` of worker pools and
+	// selects the disks on the nodes volumes are provisioned from.
+	Type string
+	// Provisioner is the provisioner of the StorageClass. Only local static volumes (`kubernetes.io/no-provisioner`)
+	// are supported, which is also the default.
+	Provisioner *string
+	// Parameters are additional parameters of the StorageClass.
+	Parameters map[string]string
+	// ReclaimPolicy is the reclaim policy of volumes provisioned by the StorageClass.
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy
+	// VolumeBindingMode is the volume binding mode of the StorageClass.
+	VolumeBindingMode *storagev1.VolumeBindingMode
 }
 
 // MachineImageVersion contains a version and a provider-specific identifier.
@@ -22,6 +22,9 @@ type ControlPlaneConfig struct {
 
 	// NodeNamePolicy is a policy for generating hostnames for the worker nodes.
 	NodeNamePolicy NodeNamePolicy
+
+	// Storage contains configuration settings for the node-local storage of the shoot.
+	Storage *StorageConfig
 }
 
 // NodeNamePolicy is a policy for generating hostnames for the worker nodes.
@@ -36,6 +39,16 @@ const (
 	NodeNamePolicyServerClaimName NodeNamePolicy = "ServerClaimName"
 )
 
+// StorageConfig contains configuration settings for the node-local storage of the shoot.
+type StorageConfig struct {
+	// StorageClasses is a list of names of StorageClasses defined in the CloudProfile which are deployed into
+	// the shoot. If empty, all StorageClasses of the CloudProfile are deployed.
+	StorageClasses []string
+	// DefaultStorageClass is the name of the StorageClass which is marked as default in the shoot.
+	// Defaults to the default StorageClass of the CloudProfile.
+	DefaultStorageClass *string
+}
+
 // CloudControllerNetworking contains configuration settings for CCM networking.
 type CloudControllerNetworking struct {
 	// ConfigureNodeAddresses enables the configuration of node addresses.
@@ -31,6 +31,9 @@ type Networks struct {
 	Name string
 	// CIDR is the workers subnet range to create.
 	CIDR string
+	// AdditionalCIDRs are further subnet ranges of the network of other IP families, e.g. the IPv6 range of a
+	// dual-stack network.
+	AdditionalCIDRs []string
 	// ID is the ID for the workers' subnet.
 	ID string
 }
@@ -21,6 +21,15 @@ type WorkerConfig struct {
 	IPAMConfig []IPAMConfig
 	// Metadata is a key-value map of additional data which should be passed to the Machine.
 	Metadata map[string]string
+	// RenderTemplates renders the values of Metadata and ExtraIgnition.Raw as Go templates per zone with the shoot
+	// and worker pool variables.
+	RenderTemplates bool
+	// RolloutOnConfigChange includes the worker configuration and the content of the referenced ignition secret in
+	// the worker pool hash, so that changes to them roll the machines of the worker pool. Changes to Metadata never
+	// roll the machines; they are applied to the machine class in place and take effect for new machines.
+	RolloutOnConfigChange bool
+	// NodeNamePolicy overrides the NodeNamePolicy of the ControlPlaneConfig for the nodes of the worker pool.
+	NodeNamePolicy NodeNamePolicy
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -51,11 +60,23 @@ type MachineImage struct {
 
 // IgnitionConfig contains ignition settings.
 type IgnitionConfig struct {
-	Raw       string
-	SecretRef string
-	Override  bool
+	Raw        string
+	SecretRef  string
+	SecretRefs []string
+	Override   bool
+	Format     IgnitionFormat
 }
 
+// IgnitionFormat is the format of an extra ignition config.
+type IgnitionFormat string
+
+const (
+	// IgnitionFormatIgnition is the format of an Ignition config in JSON or YAML.
+	IgnitionFormatIgnition IgnitionFormat = "ignition"
+	// IgnitionFormatButane is the format of a Butane config, which is translated to Ignition.
+	IgnitionFormatButane IgnitionFormat = "butane"
+)
+
 // IPAMObjectReference is a reference to the IPAM object, which will be used for IP allocation.
 type IPAMObjectReference struct {
 	// Name is the name of resource being referenced.
@@ -0,0 +1,31 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// WorkloadIdentityConfig contains configuration settings for the access of Shoots to the metal API with a Gardener
+// WorkloadIdentity.
+type WorkloadIdentityConfig struct {
+	metav1.TypeMeta
+	// Namespace is the namespace in the metal cluster in which the resources of the Shoots are managed.
+	Namespace string
+	// TokenExchange configures the exchange of the workload identity token for a token of the metal cluster. If not
+	// set, the metal cluster must trust the issuer of the workload identity tokens, which are then used as they are.
+	TokenExchange *TokenExchangeConfig
+}
+
+// TokenExchangeConfig configures an OAuth 2.0 token exchange (RFC 8693) endpoint.
+type TokenExchangeConfig struct {
+	// URL is the URL of the token exchange endpoint.
+	URL string
+	// Audience is the audience requested for the exchanged token.
+	Audience string
+	// CertificateAuthorityData contains PEM-encoded certificate authorities for the token exchange endpoint.
+	CertificateAuthorityData []byte
+}
@@ -4,6 +4,9 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -20,11 +23,49 @@ type CloudProfileConfig struct {
 	// RegionConfigs is the list of supported regions.
 	RegionConfigs []RegionConfig `json:"regionConfigs,omitempty"`
 	MachineTypes  []MachineType  `json:"machineTypes,omitempty"`
+	// StorageClasses defines the node-local StorageClasses which can be deployed into shoots.
+	// +optional
+	StorageClasses StorageClasses `json:"storageClasses,omitempty"`
 }
 
 type MachineType struct {
 	Name         string            `json:"name"`
 	ServerLabels map[string]string `json:"serverLabels,omitempty"`
+	// Volumes are the volumes offered by the servers of this machine type. If set, the volume of a worker pool
+	// using this machine type must be satisfied by one of them.
+	// +optional
+	Volumes []MachineTypeVolume `json:"volumes,omitempty"`
+	// Capacity is the capacity of the servers of this machine type. If set, the resources reserved for the kubelet
+	// and the system as well as the CPU and topology manager policies of the nodes are derived from it.
+	// +optional
+	Capacity *MachineTypeCapacity `json:"capacity,omitempty"`
+	// Priority is the priority of this machine type for the priority expander of the cluster-autoscaler. Worker
+	// pools of machine types with higher priorities are preferred on scale-up, e.g. cheap or abundant servers.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+}
+
+// MachineTypeCapacity is the capacity of the servers of a machine type.
+type MachineTypeCapacity struct {
+	// CPU is the number of CPUs of the servers.
+	CPU resource.Quantity `json:"cpu"`
+	// Memory is the memory of the servers.
+	Memory resource.Quantity `json:"memory"`
+	// HugePages maps huge page sizes to the amount of memory of the servers which is preallocated as huge pages
+	// of that size, e.g. `2Mi: 8Gi`.
+	// +optional
+	HugePages map[string]resource.Quantity `json:"hugePages,omitempty"`
+}
+
+// MachineTypeVolume is a volume offered by the servers of a machine type.
+type MachineTypeVolume struct {
+	// Type is the volume type, e.g. the disk technology.
+	Type string `json:"type"`
+	// Size is the capacity of the volume.
+	Size resource.Quantity `json:"size"`
+	// ServerLabels are additional labels selecting servers which offer this volume.
+	// +optional
+	ServerLabels map[string]string `json:"serverLabels,omitempty"`
 }
 
 // MachineImages is a mapping from logical names and versions to provider-specific identifiers.
@@ -41,8 +82,80 @@ type RegionConfig struct {
 	Name string `json:"name"`
 	// Server is the server endpoint of this region.
 	Server string `json:"server"`
+	// FailoverServers is an ordered list of additional server endpoints of this region. The clients of the extension
+	// fail over to them if the server endpoints before them are unavailable.
+	// +optional
+	FailoverServers []string `json:"failoverServers,omitempty"`
 	// CertificateAuthorityData is the CA data of the region server.
 	CertificateAuthorityData []byte `json:"certificateAuthorityData"`
+	// ProxyURL is the URL of the proxy through which the region server is reached, e.g. an HTTP CONNECT proxy.
+	// +optional
+	ProxyURL string `json:"proxyURL,omitempty"`
+	// TLSServerName is the name used to verify the serving certificate of the region server, e.g. if the server is
+	// reached through an egress gateway under a different name.
+	// +optional
+	TLSServerName string `json:"tlsServerName,omitempty"`
+	// AdditionalCertificateAuthorityData is a bundle of PEM encoded CA certificates which are trusted in addition to
+	// CertificateAuthorityData, e.g. the CA of an egress gateway. It requires CertificateAuthorityData to be set.
+	// +optional
+	AdditionalCertificateAuthorityData []byte `json:"additionalCertificateAuthorityData,omitempty"`
+	// EgressCIDRs are the CIDRs under which the region server, or its proxy if ProxyURL is set, is reachable from the
+	// seeds. They are required for the egress network policy of the control plane components if the server or the
+	// proxy is addressed by a host name.
+	// +optional
+	EgressCIDRs []string `json:"egressCIDRs,omitempty"`
+	// Node contains settings of the operating system of all nodes in this region.
+	// +optional
+	Node *RegionNodeConfig `json:"node,omitempty"`
+}
+
+// RegionNodeConfig contains settings of the operating system of the nodes in a region.
+type RegionNodeConfig struct {
+	// NTPServers are the NTP servers the nodes synchronize their clocks with.
+	// +optional
+	NTPServers []string `json:"ntpServers,omitempty"`
+	// DNSServers are the IP addresses of the DNS resolvers of the nodes.
+	// +optional
+	DNSServers []string `json:"dnsServers,omitempty"`
+	// LLDP enables the LLDP daemon on the nodes.
+	// +optional
+	LLDP bool `json:"lldp,omitempty"`
+	// CABundle is a bundle of PEM encoded certificates which are additionally trusted by the nodes, e.g. the CA of
+	// an internal registry.
+	// +optional
+	CABundle string `json:"caBundle,omitempty"`
+}
+
+// StorageClasses is a definition of a default and additional StorageClasses.
+type StorageClasses struct {
+	// Default is the default StorageClass.
+	// +optional
+	Default *StorageClass `json:"default,omitempty"`
+	// Additional is a list of additional StorageClasses.
+	// +optional
+	Additional []StorageClass `json:"additional,omitempty"`
+}
+
+// StorageClass is a definition of a node-local StorageClass.
+type StorageClass struct {
+	// Name is the name of the StorageClass.
+	Name string `json:"name"`
+	// Type is the volume type backing the StorageClass. It corresponds to the `volume.type` of worker pools and
+	// selects the disks on the nodes volumes are provisioned from.
+	Type string `json:"type"`
+	// Provisioner is the provisioner of the StorageClass. Only local static volumes (`kubernetes.io/no-provisioner`)
+	// are supported, which is also the default.
+	// +optional
+	Provisioner *string `json:"provisioner,omitempty"`
+	// Parameters are additional parameters of the StorageClass.
+	// +optional
+	Parameters map[string]string `json:"parameters,omitempty"`
+	// ReclaimPolicy is the reclaim policy of volumes provisioned by the StorageClass.
+	// +optional
+	ReclaimPolicy *corev1.PersistentVolumeReclaimPolicy `json:"reclaimPolicy,omitempty"`
+	// VolumeBindingMode is the volume binding mode of the StorageClass.
+	// +optional
+	VolumeBindingMode *storagev1.VolumeBindingMode `json:"volumeBindingMode,omitempty"`
 }
 
 // MachineImageVersion contains a version and a provider-specific identifier.
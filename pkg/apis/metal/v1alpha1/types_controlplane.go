@@ -23,7 +23,13 @@ type ControlPlaneConfig struct {
 	LoadBalancerConfig *LoadBalancerConfig `json:"loadBalancerConfig,omitempty"`
 
 	// NodeNamePolicy is a policy for generating hostnames for the worker nodes.
+	// It is immutable, as changing it renames the nodes.
+	// +optional
 	NodeNamePolicy NodeNamePolicy `json:"nodeNamePolicy,omitempty"`
+
+	// Storage contains configuration settings for the node-local storage of the shoot.
+	// +optional
+	Storage *StorageConfig `json:"storage,omitempty"`
 }
 
 // NodeNamePolicy is a policy for generating hostnames for the worker nodes.
@@ -38,6 +44,18 @@ const (
 	NodeNamePolicyServerClaimName NodeNamePolicy = "ServerClaimName"
 )
 
+// StorageConfig contains configuration settings for the node-local storage of the shoot.
+type StorageConfig struct {
+	// StorageClasses is a list of names of StorageClasses defined in the CloudProfile which are deployed into
+	// the shoot. If empty, all StorageClasses of the CloudProfile are deployed.
+	// +optional
+	StorageClasses []string `json:"storageClasses,omitempty"`
+	// DefaultStorageClass is the name of the StorageClass which is marked as default in the shoot.
+	// Defaults to the default StorageClass of the CloudProfile.
+	// +optional
+	DefaultStorageClass *string `json:"defaultStorageClass,omitempty"`
+}
+
 // CloudControllerNetworking contains configuration settings for CCM networking.
 type CloudControllerNetworking struct {
 	// ConfigureNodeAddresses enables the configuration of node addresses.
@@ -33,6 +33,10 @@ type Networks struct {
 	Name string `json:"name"`
 	// CIDR is the workers subnet range to create.
 	CIDR string `json:"cidr"`
+	// AdditionalCIDRs are further subnet ranges of the network of other IP families, e.g. the IPv6 range of a
+	// dual-stack network.
+	// +optional
+	AdditionalCIDRs []string `json:"additionalCIDRs,omitempty"`
 	// ID is the ID for the workers' subnet.
 	// +optional
 	ID string `json:"id,omitempty"`
@@ -24,6 +24,19 @@ type WorkerConfig struct {
 	// Metadata is a key-value map of additional data which should be passed to the Machine.
 	// +optional
 	Metadata map[string]string `json:"metadata,omitempty"`
+	// RenderTemplates renders the values of Metadata and ExtraIgnition.Raw as Go templates per zone with the shoot
+	// and worker pool variables. Without it, they are passed on as is.
+	// +optional
+	RenderTemplates bool `json:"renderTemplates,omitempty"`
+	// RolloutOnConfigChange includes the worker configuration and the content of the referenced ignition secret in
+	// the worker pool hash, so that changes to them roll the machines of the worker pool. Changes to Metadata never
+	// roll the machines; they are applied to the machine class in place and take effect for new machines.
+	// +optional
+	RolloutOnConfigChange bool `json:"rolloutOnConfigChange,omitempty"`
+	// NodeNamePolicy overrides the NodeNamePolicy of the ControlPlaneConfig for the nodes of the worker pool.
+	// It is immutable, as changing it renames the nodes.
+	// +optional
+	NodeNamePolicy NodeNamePolicy `json:"nodeNamePolicy,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -57,6 +70,7 @@ type MachineImage struct {
 // IgnitionConfig contains ignition settings.
 type IgnitionConfig struct {
 	// Raw contains an inline ignition config, which is merged with the config from the os extension.
+	// It is rendered as Go template per zone if RenderTemplates is set.
 	// +optional
 	Raw string `json:"raw,omitempty"`
 
@@ -64,12 +78,32 @@ type IgnitionConfig struct {
 	// +optional
 	SecretRef string `json:"secretRef,omitempty"`
 
+	// SecretRefs is a list of references to resources in the shoot spec referencing secrets containing ignition
+	// configs. They are merged in the given order after Raw and SecretRef.
+	// +optional
+	SecretRefs []string `json:"secretRefs,omitempty"`
+
 	// Override configures, if ignition keys set by the os-extension are overridden
 	// by extra ignition.
 	// +optional
 	Override bool `json:"override,omitempty"`
+
+	// Format is the format of Raw and the content of the referenced secrets. Butane configs are translated to
+	// Ignition before they are merged. Defaults to ignition.
+	// +optional
+	Format IgnitionFormat `json:"format,omitempty"`
 }
 
+// IgnitionFormat is the format of an extra ignition config.
+type IgnitionFormat string
+
+const (
+	// IgnitionFormatIgnition is the format of an Ignition config in JSON or YAML.
+	IgnitionFormatIgnition IgnitionFormat = "ignition"
+	// IgnitionFormatButane is the format of a Butane config, which is translated to Ignition.
+	IgnitionFormatButane IgnitionFormat = "butane"
+)
+
 // IPAMObjectReference is a reference to the IPAM object, which will be used for IP allocation.
 type IPAMObjectReference struct {
 	// Name is the name of resource being referenced.
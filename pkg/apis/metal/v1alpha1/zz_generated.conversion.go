@@ -13,6 +13,9 @@ import (
 	unsafe "unsafe"
 
 	metal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -214,6 +217,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*MachineTypeCapacity)(nil), (*metal.MachineTypeCapacity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineTypeCapacity_To_metal_MachineTypeCapacity(a.(*MachineTypeCapacity), b.(*metal.MachineTypeCapacity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.MachineTypeCapacity)(nil), (*MachineTypeCapacity)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_MachineTypeCapacity_To_v1alpha1_MachineTypeCapacity(a.(*metal.MachineTypeCapacity), b.(*MachineTypeCapacity), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*MachineTypeVolume)(nil), (*metal.MachineTypeVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_MachineTypeVolume_To_metal_MachineTypeVolume(a.(*MachineTypeVolume), b.(*metal.MachineTypeVolume), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.MachineTypeVolume)(nil), (*MachineTypeVolume)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_MachineTypeVolume_To_v1alpha1_MachineTypeVolume(a.(*metal.MachineTypeVolume), b.(*MachineTypeVolume), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*MetalLoadBalancerConfig)(nil), (*metal.MetalLoadBalancerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_MetalLoadBalancerConfig_To_metal_MetalLoadBalancerConfig(a.(*MetalLoadBalancerConfig), b.(*metal.MetalLoadBalancerConfig), scope)
 	}); err != nil {
@@ -254,6 +277,56 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*RegionNodeConfig)(nil), (*metal.RegionNodeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_RegionNodeConfig_To_metal_RegionNodeConfig(a.(*RegionNodeConfig), b.(*metal.RegionNodeConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.RegionNodeConfig)(nil), (*RegionNodeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_RegionNodeConfig_To_v1alpha1_RegionNodeConfig(a.(*metal.RegionNodeConfig), b.(*RegionNodeConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageClass)(nil), (*metal.StorageClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageClass_To_metal_StorageClass(a.(*StorageClass), b.(*metal.StorageClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.StorageClass)(nil), (*StorageClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_StorageClass_To_v1alpha1_StorageClass(a.(*metal.StorageClass), b.(*StorageClass), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageClasses)(nil), (*metal.StorageClasses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageClasses_To_metal_StorageClasses(a.(*StorageClasses), b.(*metal.StorageClasses), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.StorageClasses)(nil), (*StorageClasses)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_StorageClasses_To_v1alpha1_StorageClasses(a.(*metal.StorageClasses), b.(*StorageClasses), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*StorageConfig)(nil), (*metal.StorageConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_StorageConfig_To_metal_StorageConfig(a.(*StorageConfig), b.(*metal.StorageConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.StorageConfig)(nil), (*StorageConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_StorageConfig_To_v1alpha1_StorageConfig(a.(*metal.StorageConfig), b.(*StorageConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*TokenExchangeConfig)(nil), (*metal.TokenExchangeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_TokenExchangeConfig_To_metal_TokenExchangeConfig(a.(*TokenExchangeConfig), b.(*metal.TokenExchangeConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.TokenExchangeConfig)(nil), (*TokenExchangeConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_TokenExchangeConfig_To_v1alpha1_TokenExchangeConfig(a.(*metal.TokenExchangeConfig), b.(*TokenExchangeConfig), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddGeneratedConversionFunc((*WorkerConfig)(nil), (*metal.WorkerConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(a.(*WorkerConfig), b.(*metal.WorkerConfig), scope)
 	}); err != nil {
@@ -274,6 +347,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddGeneratedConversionFunc((*WorkloadIdentityConfig)(nil), (*metal.WorkloadIdentityConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_WorkloadIdentityConfig_To_metal_WorkloadIdentityConfig(a.(*WorkloadIdentityConfig), b.(*metal.WorkloadIdentityConfig), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*metal.WorkloadIdentityConfig)(nil), (*WorkloadIdentityConfig)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_metal_WorkloadIdentityConfig_To_v1alpha1_WorkloadIdentityConfig(a.(*metal.WorkloadIdentityConfig), b.(*WorkloadIdentityConfig), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -435,6 +518,9 @@ func autoConvert_v1alpha1_CloudProfileConfig_To_metal_CloudProfileConfig(in *Clo
 	out.MachineImages = *(*[]metal.MachineImages)(unsafe.Pointer(&in.MachineImages))
 	out.RegionConfigs = *(*[]metal.RegionConfig)(unsafe.Pointer(&in.RegionConfigs))
 	out.MachineTypes = *(*[]metal.MachineType)(unsafe.Pointer(&in.MachineTypes))
+	if err := Convert_v1alpha1_StorageClasses_To_metal_StorageClasses(&in.StorageClasses, &out.StorageClasses, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -447,6 +533,9 @@ func autoConvert_metal_CloudProfileConfig_To_v1alpha1_CloudProfileConfig(in *met
 	out.MachineImages = *(*[]MachineImages)(unsafe.Pointer(&in.MachineImages))
 	out.RegionConfigs = *(*[]RegionConfig)(unsafe.Pointer(&in.RegionConfigs))
 	out.MachineTypes = *(*[]MachineType)(unsafe.Pointer(&in.MachineTypes))
+	if err := Convert_metal_StorageClasses_To_v1alpha1_StorageClasses(&in.StorageClasses, &out.StorageClasses, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -459,6 +548,7 @@ func autoConvert_v1alpha1_ControlPlaneConfig_To_metal_ControlPlaneConfig(in *Con
 	out.CloudControllerManager = (*metal.CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
 	out.LoadBalancerConfig = (*metal.LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancerConfig))
 	out.NodeNamePolicy = metal.NodeNamePolicy(in.NodeNamePolicy)
+	out.Storage = (*metal.StorageConfig)(unsafe.Pointer(in.Storage))
 	return nil
 }
 
@@ -471,6 +561,7 @@ func autoConvert_metal_ControlPlaneConfig_To_v1alpha1_ControlPlaneConfig(in *met
 	out.CloudControllerManager = (*CloudControllerManagerConfig)(unsafe.Pointer(in.CloudControllerManager))
 	out.LoadBalancerConfig = (*LoadBalancerConfig)(unsafe.Pointer(in.LoadBalancerConfig))
 	out.NodeNamePolicy = NodeNamePolicy(in.NodeNamePolicy)
+	out.Storage = (*StorageConfig)(unsafe.Pointer(in.Storage))
 	return nil
 }
 
@@ -550,7 +641,9 @@ func Convert_metal_IPAMObjectReference_To_v1alpha1_IPAMObjectReference(in *metal
 func autoConvert_v1alpha1_IgnitionConfig_To_metal_IgnitionConfig(in *IgnitionConfig, out *metal.IgnitionConfig, s conversion.Scope) error {
 	out.Raw = in.Raw
 	out.SecretRef = in.SecretRef
+	out.SecretRefs = *(*[]string)(unsafe.Pointer(&in.SecretRefs))
 	out.Override = in.Override
+	out.Format = metal.IgnitionFormat(in.Format)
 	return nil
 }
 
@@ -562,7 +655,9 @@ func Convert_v1alpha1_IgnitionConfig_To_metal_IgnitionConfig(in *IgnitionConfig,
 func autoConvert_metal_IgnitionConfig_To_v1alpha1_IgnitionConfig(in *metal.IgnitionConfig, out *IgnitionConfig, s conversion.Scope) error {
 	out.Raw = in.Raw
 	out.SecretRef = in.SecretRef
+	out.SecretRefs = *(*[]string)(unsafe.Pointer(&in.SecretRefs))
 	out.Override = in.Override
+	out.Format = IgnitionFormat(in.Format)
 	return nil
 }
 
@@ -708,6 +803,9 @@ func Convert_metal_MachineImages_To_v1alpha1_MachineImages(in *metal.MachineImag
 func autoConvert_v1alpha1_MachineType_To_metal_MachineType(in *MachineType, out *metal.MachineType, s conversion.Scope) error {
 	out.Name = in.Name
 	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	out.Volumes = *(*[]metal.MachineTypeVolume)(unsafe.Pointer(&in.Volumes))
+	out.Capacity = (*metal.MachineTypeCapacity)(unsafe.Pointer(in.Capacity))
+	out.Priority = (*int32)(unsafe.Pointer(in.Priority))
 	return nil
 }
 
@@ -719,6 +817,9 @@ func Convert_v1alpha1_MachineType_To_metal_MachineType(in *MachineType, out *met
 func autoConvert_metal_MachineType_To_v1alpha1_MachineType(in *metal.MachineType, out *MachineType, s conversion.Scope) error {
 	out.Name = in.Name
 	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	out.Volumes = *(*[]MachineTypeVolume)(unsafe.Pointer(&in.Volumes))
+	out.Capacity = (*MachineTypeCapacity)(unsafe.Pointer(in.Capacity))
+	out.Priority = (*int32)(unsafe.Pointer(in.Priority))
 	return nil
 }
 
@@ -727,6 +828,54 @@ func Convert_metal_MachineType_To_v1alpha1_MachineType(in *metal.MachineType, ou
 	return autoConvert_metal_MachineType_To_v1alpha1_MachineType(in, out, s)
 }
 
+func autoConvert_v1alpha1_MachineTypeCapacity_To_metal_MachineTypeCapacity(in *MachineTypeCapacity, out *metal.MachineTypeCapacity, s conversion.Scope) error {
+	out.CPU = in.CPU
+	out.Memory = in.Memory
+	out.HugePages = *(*map[string]resource.Quantity)(unsafe.Pointer(&in.HugePages))
+	return nil
+}
+
+// Convert_v1alpha1_MachineTypeCapacity_To_metal_MachineTypeCapacity is an autogenerated conversion function.
+func Convert_v1alpha1_MachineTypeCapacity_To_metal_MachineTypeCapacity(in *MachineTypeCapacity, out *metal.MachineTypeCapacity, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineTypeCapacity_To_metal_MachineTypeCapacity(in, out, s)
+}
+
+func autoConvert_metal_MachineTypeCapacity_To_v1alpha1_MachineTypeCapacity(in *metal.MachineTypeCapacity, out *MachineTypeCapacity, s conversion.Scope) error {
+	out.CPU = in.CPU
+	out.Memory = in.Memory
+	out.HugePages = *(*map[string]resource.Quantity)(unsafe.Pointer(&in.HugePages))
+	return nil
+}
+
+// Convert_metal_MachineTypeCapacity_To_v1alpha1_MachineTypeCapacity is an autogenerated conversion function.
+func Convert_metal_MachineTypeCapacity_To_v1alpha1_MachineTypeCapacity(in *metal.MachineTypeCapacity, out *MachineTypeCapacity, s conversion.Scope) error {
+	return autoConvert_metal_MachineTypeCapacity_To_v1alpha1_MachineTypeCapacity(in, out, s)
+}
+
+func autoConvert_v1alpha1_MachineTypeVolume_To_metal_MachineTypeVolume(in *MachineTypeVolume, out *metal.MachineTypeVolume, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Size = in.Size
+	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	return nil
+}
+
+// Convert_v1alpha1_MachineTypeVolume_To_metal_MachineTypeVolume is an autogenerated conversion function.
+func Convert_v1alpha1_MachineTypeVolume_To_metal_MachineTypeVolume(in *MachineTypeVolume, out *metal.MachineTypeVolume, s conversion.Scope) error {
+	return autoConvert_v1alpha1_MachineTypeVolume_To_metal_MachineTypeVolume(in, out, s)
+}
+
+func autoConvert_metal_MachineTypeVolume_To_v1alpha1_MachineTypeVolume(in *metal.MachineTypeVolume, out *MachineTypeVolume, s conversion.Scope) error {
+	out.Type = in.Type
+	out.Size = in.Size
+	out.ServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ServerLabels))
+	return nil
+}
+
+// Convert_metal_MachineTypeVolume_To_v1alpha1_MachineTypeVolume is an autogenerated conversion function.
+func Convert_metal_MachineTypeVolume_To_v1alpha1_MachineTypeVolume(in *metal.MachineTypeVolume, out *MachineTypeVolume, s conversion.Scope) error {
+	return autoConvert_metal_MachineTypeVolume_To_v1alpha1_MachineTypeVolume(in, out, s)
+}
+
 func autoConvert_v1alpha1_MetalLoadBalancerConfig_To_metal_MetalLoadBalancerConfig(in *MetalLoadBalancerConfig, out *metal.MetalLoadBalancerConfig, s conversion.Scope) error {
 	out.NodeCIDRMask = in.NodeCIDRMask
 	out.AllocateNodeCIDRs = in.AllocateNodeCIDRs
@@ -780,6 +929,7 @@ func Convert_metal_MetallbConfig_To_v1alpha1_MetallbConfig(in *metal.MetallbConf
 func autoConvert_v1alpha1_Networks_To_metal_Networks(in *Networks, out *metal.Networks, s conversion.Scope) error {
 	out.Name = in.Name
 	out.CIDR = in.CIDR
+	out.AdditionalCIDRs = *(*[]string)(unsafe.Pointer(&in.AdditionalCIDRs))
 	out.ID = in.ID
 	return nil
 }
@@ -792,6 +942,7 @@ func Convert_v1alpha1_Networks_To_metal_Networks(in *Networks, out *metal.Networ
 func autoConvert_metal_Networks_To_v1alpha1_Networks(in *metal.Networks, out *Networks, s conversion.Scope) error {
 	out.Name = in.Name
 	out.CIDR = in.CIDR
+	out.AdditionalCIDRs = *(*[]string)(unsafe.Pointer(&in.AdditionalCIDRs))
 	out.ID = in.ID
 	return nil
 }
@@ -804,7 +955,13 @@ func Convert_metal_Networks_To_v1alpha1_Networks(in *metal.Networks, out *Networ
 func autoConvert_v1alpha1_RegionConfig_To_metal_RegionConfig(in *RegionConfig, out *metal.RegionConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Server = in.Server
+	out.FailoverServers = *(*[]string)(unsafe.Pointer(&in.FailoverServers))
 	out.CertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthorityData))
+	out.ProxyURL = in.ProxyURL
+	out.TLSServerName = in.TLSServerName
+	out.AdditionalCertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.AdditionalCertificateAuthorityData))
+	out.EgressCIDRs = *(*[]string)(unsafe.Pointer(&in.EgressCIDRs))
+	out.Node = (*metal.RegionNodeConfig)(unsafe.Pointer(in.Node))
 	return nil
 }
 
@@ -816,7 +973,13 @@ func Convert_v1alpha1_RegionConfig_To_metal_RegionConfig(in *RegionConfig, out *
 func autoConvert_metal_RegionConfig_To_v1alpha1_RegionConfig(in *metal.RegionConfig, out *RegionConfig, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Server = in.Server
+	out.FailoverServers = *(*[]string)(unsafe.Pointer(&in.FailoverServers))
 	out.CertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthorityData))
+	out.ProxyURL = in.ProxyURL
+	out.TLSServerName = in.TLSServerName
+	out.AdditionalCertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.AdditionalCertificateAuthorityData))
+	out.EgressCIDRs = *(*[]string)(unsafe.Pointer(&in.EgressCIDRs))
+	out.Node = (*RegionNodeConfig)(unsafe.Pointer(in.Node))
 	return nil
 }
 
@@ -825,11 +988,138 @@ func Convert_metal_RegionConfig_To_v1alpha1_RegionConfig(in *metal.RegionConfig,
 	return autoConvert_metal_RegionConfig_To_v1alpha1_RegionConfig(in, out, s)
 }
 
+func autoConvert_v1alpha1_RegionNodeConfig_To_metal_RegionNodeConfig(in *RegionNodeConfig, out *metal.RegionNodeConfig, s conversion.Scope) error {
+	out.NTPServers = *(*[]string)(unsafe.Pointer(&in.NTPServers))
+	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
+	out.LLDP = in.LLDP
+	out.CABundle = in.CABundle
+	return nil
+}
+
+// Convert_v1alpha1_RegionNodeConfig_To_metal_RegionNodeConfig is an autogenerated conversion function.
+func Convert_v1alpha1_RegionNodeConfig_To_metal_RegionNodeConfig(in *RegionNodeConfig, out *metal.RegionNodeConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_RegionNodeConfig_To_metal_RegionNodeConfig(in, out, s)
+}
+
+func autoConvert_metal_RegionNodeConfig_To_v1alpha1_RegionNodeConfig(in *metal.RegionNodeConfig, out *RegionNodeConfig, s conversion.Scope) error {
+	out.NTPServers = *(*[]string)(unsafe.Pointer(&in.NTPServers))
+	out.DNSServers = *(*[]string)(unsafe.Pointer(&in.DNSServers))
+	out.LLDP = in.LLDP
+	out.CABundle = in.CABundle
+	return nil
+}
+
+// Convert_metal_RegionNodeConfig_To_v1alpha1_RegionNodeConfig is an autogenerated conversion function.
+func Convert_metal_RegionNodeConfig_To_v1alpha1_RegionNodeConfig(in *metal.RegionNodeConfig, out *RegionNodeConfig, s conversion.Scope) error {
+	return autoConvert_metal_RegionNodeConfig_To_v1alpha1_RegionNodeConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageClass_To_metal_StorageClass(in *StorageClass, out *metal.StorageClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.Provisioner = (*string)(unsafe.Pointer(in.Provisioner))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	out.ReclaimPolicy = (*v1.PersistentVolumeReclaimPolicy)(unsafe.Pointer(in.ReclaimPolicy))
+	out.VolumeBindingMode = (*storagev1.VolumeBindingMode)(unsafe.Pointer(in.VolumeBindingMode))
+	return nil
+}
+
+// Convert_v1alpha1_StorageClass_To_metal_StorageClass is an autogenerated conversion function.
+func Convert_v1alpha1_StorageClass_To_metal_StorageClass(in *StorageClass, out *metal.StorageClass, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageClass_To_metal_StorageClass(in, out, s)
+}
+
+func autoConvert_metal_StorageClass_To_v1alpha1_StorageClass(in *metal.StorageClass, out *StorageClass, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Type = in.Type
+	out.Provisioner = (*string)(unsafe.Pointer(in.Provisioner))
+	out.Parameters = *(*map[string]string)(unsafe.Pointer(&in.Parameters))
+	out.ReclaimPolicy = (*v1.PersistentVolumeReclaimPolicy)(unsafe.Pointer(in.ReclaimPolicy))
+	out.VolumeBindingMode = (*storagev1.VolumeBindingMode)(unsafe.Pointer(in.VolumeBindingMode))
+	return nil
+}
+
+// Convert_metal_StorageClass_To_v1alpha1_StorageClass is an autogenerated conversion function.
+func Convert_metal_StorageClass_To_v1alpha1_StorageClass(in *metal.StorageClass, out *StorageClass, s conversion.Scope) error {
+	return autoConvert_metal_StorageClass_To_v1alpha1_StorageClass(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageClasses_To_metal_StorageClasses(in *StorageClasses, out *metal.StorageClasses, s conversion.Scope) error {
+	out.Default = (*metal.StorageClass)(unsafe.Pointer(in.Default))
+	out.Additional = *(*[]metal.StorageClass)(unsafe.Pointer(&in.Additional))
+	return nil
+}
+
+// Convert_v1alpha1_StorageClasses_To_metal_StorageClasses is an autogenerated conversion function.
+func Convert_v1alpha1_StorageClasses_To_metal_StorageClasses(in *StorageClasses, out *metal.StorageClasses, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageClasses_To_metal_StorageClasses(in, out, s)
+}
+
+func autoConvert_metal_StorageClasses_To_v1alpha1_StorageClasses(in *metal.StorageClasses, out *StorageClasses, s conversion.Scope) error {
+	out.Default = (*StorageClass)(unsafe.Pointer(in.Default))
+	out.Additional = *(*[]StorageClass)(unsafe.Pointer(&in.Additional))
+	return nil
+}
+
+// Convert_metal_StorageClasses_To_v1alpha1_StorageClasses is an autogenerated conversion function.
+func Convert_metal_StorageClasses_To_v1alpha1_StorageClasses(in *metal.StorageClasses, out *StorageClasses, s conversion.Scope) error {
+	return autoConvert_metal_StorageClasses_To_v1alpha1_StorageClasses(in, out, s)
+}
+
+func autoConvert_v1alpha1_StorageConfig_To_metal_StorageConfig(in *StorageConfig, out *metal.StorageConfig, s conversion.Scope) error {
+	out.StorageClasses = *(*[]string)(unsafe.Pointer(&in.StorageClasses))
+	out.DefaultStorageClass = (*string)(unsafe.Pointer(in.DefaultStorageClass))
+	return nil
+}
+
+// Convert_v1alpha1_StorageConfig_To_metal_StorageConfig is an autogenerated conversion function.
+func Convert_v1alpha1_StorageConfig_To_metal_StorageConfig(in *StorageConfig, out *metal.StorageConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_StorageConfig_To_metal_StorageConfig(in, out, s)
+}
+
+func autoConvert_metal_StorageConfig_To_v1alpha1_StorageConfig(in *metal.StorageConfig, out *StorageConfig, s conversion.Scope) error {
+	out.StorageClasses = *(*[]string)(unsafe.Pointer(&in.StorageClasses))
+	out.DefaultStorageClass = (*string)(unsafe.Pointer(in.DefaultStorageClass))
+	return nil
+}
+
+// Convert_metal_StorageConfig_To_v1alpha1_StorageConfig is an autogenerated conversion function.
+func Convert_metal_StorageConfig_To_v1alpha1_StorageConfig(in *metal.StorageConfig, out *StorageConfig, s conversion.Scope) error {
+	return autoConvert_metal_StorageConfig_To_v1alpha1_StorageConfig(in, out, s)
+}
+
+func autoConvert_v1alpha1_TokenExchangeConfig_To_metal_TokenExchangeConfig(in *TokenExchangeConfig, out *metal.TokenExchangeConfig, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Audience = in.Audience
+	out.CertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthorityData))
+	return nil
+}
+
+// Convert_v1alpha1_TokenExchangeConfig_To_metal_TokenExchangeConfig is an autogenerated conversion function.
+func Convert_v1alpha1_TokenExchangeConfig_To_metal_TokenExchangeConfig(in *TokenExchangeConfig, out *metal.TokenExchangeConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_TokenExchangeConfig_To_metal_TokenExchangeConfig(in, out, s)
+}
+
+func autoConvert_metal_TokenExchangeConfig_To_v1alpha1_TokenExchangeConfig(in *metal.TokenExchangeConfig, out *TokenExchangeConfig, s conversion.Scope) error {
+	out.URL = in.URL
+	out.Audience = in.Audience
+	out.CertificateAuthorityData = *(*[]byte)(unsafe.Pointer(&in.CertificateAuthorityData))
+	return nil
+}
+
+// Convert_metal_TokenExchangeConfig_To_v1alpha1_TokenExchangeConfig is an autogenerated conversion function.
+func Convert_metal_TokenExchangeConfig_To_v1alpha1_TokenExchangeConfig(in *metal.TokenExchangeConfig, out *TokenExchangeConfig, s conversion.Scope) error {
+	return autoConvert_metal_TokenExchangeConfig_To_v1alpha1_TokenExchangeConfig(in, out, s)
+}
+
 func autoConvert_v1alpha1_WorkerConfig_To_metal_WorkerConfig(in *WorkerConfig, out *metal.WorkerConfig, s conversion.Scope) error {
 	out.ExtraIgnition = (*metal.IgnitionConfig)(unsafe.Pointer(in.ExtraIgnition))
 	out.ExtraServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ExtraServerLabels))
 	out.IPAMConfig = *(*[]metal.IPAMConfig)(unsafe.Pointer(&in.IPAMConfig))
 	out.Metadata = *(*map[string]string)(unsafe.Pointer(&in.Metadata))
+	out.RenderTemplates = in.RenderTemplates
+	out.RolloutOnConfigChange = in.RolloutOnConfigChange
+	out.NodeNamePolicy = metal.NodeNamePolicy(in.NodeNamePolicy)
 	return nil
 }
 
@@ -843,6 +1133,9 @@ func autoConvert_metal_WorkerConfig_To_v1alpha1_WorkerConfig(in *metal.WorkerCon
 	out.ExtraServerLabels = *(*map[string]string)(unsafe.Pointer(&in.ExtraServerLabels))
 	out.IPAMConfig = *(*[]IPAMConfig)(unsafe.Pointer(&in.IPAMConfig))
 	out.Metadata = *(*map[string]string)(unsafe.Pointer(&in.Metadata))
+	out.RenderTemplates = in.RenderTemplates
+	out.RolloutOnConfigChange = in.RolloutOnConfigChange
+	out.NodeNamePolicy = NodeNamePolicy(in.NodeNamePolicy)
 	return nil
 }
 
@@ -870,3 +1163,25 @@ func autoConvert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in *metal.WorkerSta
 func Convert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in *metal.WorkerStatus, out *WorkerStatus, s conversion.Scope) error {
 	return autoConvert_metal_WorkerStatus_To_v1alpha1_WorkerStatus(in, out, s)
 }
+
+func autoConvert_v1alpha1_WorkloadIdentityConfig_To_metal_WorkloadIdentityConfig(in *WorkloadIdentityConfig, out *metal.WorkloadIdentityConfig, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.TokenExchange = (*metal.TokenExchangeConfig)(unsafe.Pointer(in.TokenExchange))
+	return nil
+}
+
+// Convert_v1alpha1_WorkloadIdentityConfig_To_metal_WorkloadIdentityConfig is an autogenerated conversion function.
+func Convert_v1alpha1_WorkloadIdentityConfig_To_metal_WorkloadIdentityConfig(in *WorkloadIdentityConfig, out *metal.WorkloadIdentityConfig, s conversion.Scope) error {
+	return autoConvert_v1alpha1_WorkloadIdentityConfig_To_metal_WorkloadIdentityConfig(in, out, s)
+}
+
+func autoConvert_metal_WorkloadIdentityConfig_To_v1alpha1_WorkloadIdentityConfig(in *metal.WorkloadIdentityConfig, out *WorkloadIdentityConfig, s conversion.Scope) error {
+	out.Namespace = in.Namespace
+	out.TokenExchange = (*TokenExchangeConfig)(unsafe.Pointer(in.TokenExchange))
+	return nil
+}
+
+// Convert_metal_WorkloadIdentityConfig_To_v1alpha1_WorkloadIdentityConfig is an autogenerated conversion function.
+func Convert_metal_WorkloadIdentityConfig_To_v1alpha1_WorkloadIdentityConfig(in *metal.WorkloadIdentityConfig, out *WorkloadIdentityConfig, s conversion.Scope) error {
+	return autoConvert_metal_WorkloadIdentityConfig_To_v1alpha1_WorkloadIdentityConfig(in, out, s)
+}
@@ -10,6 +10,9 @@
 package v1alpha1
 
 import (
+	v1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	resource "k8s.io/apimachinery/pkg/api/resource"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -205,6 +208,7 @@ func (in *CloudProfileConfig) DeepCopyInto(out *CloudProfileConfig) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.StorageClasses.DeepCopyInto(&out.StorageClasses)
 	return
 }
 
@@ -240,6 +244,11 @@ func (in *ControlPlaneConfig) DeepCopyInto(out *ControlPlaneConfig) {
 		*out = new(LoadBalancerConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(StorageConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -317,6 +326,11 @@ func (in *IPAMObjectReference) DeepCopy() *IPAMObjectReference {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *IgnitionConfig) DeepCopyInto(out *IgnitionConfig) {
 	*out = *in
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -337,7 +351,9 @@ func (in *InfrastructureConfig) DeepCopyInto(out *InfrastructureConfig) {
 	if in.Networks != nil {
 		in, out := &in.Networks, &out.Networks
 		*out = make([]Networks, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -491,6 +507,23 @@ func (in *MachineType) DeepCopyInto(out *MachineType) {
 			(*out)[key] = val
 		}
 	}
+	if in.Volumes != nil {
+		in, out := &in.Volumes, &out.Volumes
+		*out = make([]MachineTypeVolume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = new(MachineTypeCapacity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -504,6 +537,55 @@ func (in *MachineType) DeepCopy() *MachineType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineTypeCapacity) DeepCopyInto(out *MachineTypeCapacity) {
+	*out = *in
+	out.CPU = in.CPU.DeepCopy()
+	out.Memory = in.Memory.DeepCopy()
+	if in.HugePages != nil {
+		in, out := &in.HugePages, &out.HugePages
+		*out = make(map[string]resource.Quantity, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineTypeCapacity.
+func (in *MachineTypeCapacity) DeepCopy() *MachineTypeCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineTypeCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineTypeVolume) DeepCopyInto(out *MachineTypeVolume) {
+	*out = *in
+	out.Size = in.Size.DeepCopy()
+	if in.ServerLabels != nil {
+		in, out := &in.ServerLabels, &out.ServerLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineTypeVolume.
+func (in *MachineTypeVolume) DeepCopy() *MachineTypeVolume {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineTypeVolume)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MetalLoadBalancerConfig) DeepCopyInto(out *MetalLoadBalancerConfig) {
 	*out = *in
@@ -544,6 +626,11 @@ func (in *MetallbConfig) DeepCopy() *MetallbConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Networks) DeepCopyInto(out *Networks) {
 	*out = *in
+	if in.AdditionalCIDRs != nil {
+		in, out := &in.AdditionalCIDRs, &out.AdditionalCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -560,11 +647,31 @@ func (in *Networks) DeepCopy() *Networks {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegionConfig) DeepCopyInto(out *RegionConfig) {
 	*out = *in
+	if in.FailoverServers != nil {
+		in, out := &in.FailoverServers, &out.FailoverServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.CertificateAuthorityData != nil {
 		in, out := &in.CertificateAuthorityData, &out.CertificateAuthorityData
 		*out = make([]byte, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalCertificateAuthorityData != nil {
+		in, out := &in.AdditionalCertificateAuthorityData, &out.AdditionalCertificateAuthorityData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.EgressCIDRs != nil {
+		in, out := &in.EgressCIDRs, &out.EgressCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Node != nil {
+		in, out := &in.Node, &out.Node
+		*out = new(RegionNodeConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -578,6 +685,145 @@ func (in *RegionConfig) DeepCopy() *RegionConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegionNodeConfig) DeepCopyInto(out *RegionNodeConfig) {
+	*out = *in
+	if in.NTPServers != nil {
+		in, out := &in.NTPServers, &out.NTPServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DNSServers != nil {
+		in, out := &in.DNSServers, &out.DNSServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegionNodeConfig.
+func (in *RegionNodeConfig) DeepCopy() *RegionNodeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(RegionNodeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClass) DeepCopyInto(out *StorageClass) {
+	*out = *in
+	if in.Provisioner != nil {
+		in, out := &in.Provisioner, &out.Provisioner
+		*out = new(string)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ReclaimPolicy != nil {
+		in, out := &in.ReclaimPolicy, &out.ReclaimPolicy
+		*out = new(v1.PersistentVolumeReclaimPolicy)
+		**out = **in
+	}
+	if in.VolumeBindingMode != nil {
+		in, out := &in.VolumeBindingMode, &out.VolumeBindingMode
+		*out = new(storagev1.VolumeBindingMode)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClass.
+func (in *StorageClass) DeepCopy() *StorageClass {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageClasses) DeepCopyInto(out *StorageClasses) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(StorageClass)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make([]StorageClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageClasses.
+func (in *StorageClasses) DeepCopy() *StorageClasses {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageClasses)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageConfig) DeepCopyInto(out *StorageConfig) {
+	*out = *in
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DefaultStorageClass != nil {
+		in, out := &in.DefaultStorageClass, &out.DefaultStorageClass
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageConfig.
+func (in *StorageConfig) DeepCopy() *StorageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TokenExchangeConfig) DeepCopyInto(out *TokenExchangeConfig) {
+	*out = *in
+	if in.CertificateAuthorityData != nil {
+		in, out := &in.CertificateAuthorityData, &out.CertificateAuthorityData
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenExchangeConfig.
+func (in *TokenExchangeConfig) DeepCopy() *TokenExchangeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TokenExchangeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 	*out = *in
@@ -585,7 +831,7 @@ func (in *WorkerConfig) DeepCopyInto(out *WorkerConfig) {
 	if in.ExtraIgnition != nil {
 		in, out := &in.ExtraIgnition, &out.ExtraIgnition
 		*out = new(IgnitionConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.ExtraServerLabels != nil {
 		in, out := &in.ExtraServerLabels, &out.ExtraServerLabels
@@ -660,3 +906,33 @@ func (in *WorkerStatus) DeepCopyObject() runtime.Object {
 	}
 	return nil
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadIdentityConfig) DeepCopyInto(out *WorkloadIdentityConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	if in.TokenExchange != nil {
+		in, out := &in.TokenExchange, &out.TokenExchange
+		*out = new(TokenExchangeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadIdentityConfig.
+func (in *WorkloadIdentityConfig) DeepCopy() *WorkloadIdentityConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadIdentityConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *WorkloadIdentityConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
@@ -5,16 +5,24 @@ package validation
 
 import (
 	"fmt"
+	"net"
+	"net/url"
+	"strings"
 
 	gardenercore "github.com/gardener/gardener/pkg/apis/core"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/gardener/gardener/pkg/utils"
 	gutil "github.com/gardener/gardener/pkg/utils/gardener"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/cert"
 	"k8s.io/utils/ptr"
 	"k8s.io/utils/strings/slices"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
 // ValidateCloudProfileConfig validates a CloudProfileConfig object.
@@ -28,6 +36,181 @@ func ValidateCloudProfileConfig(cpConfig *apismetal.CloudProfileConfig, machineI
 		allErrs = append(allErrs, ValidateProviderMachineImage(idxPath, machineImage)...)
 	}
 	allErrs = append(allErrs, validateProviderImagesMapping(cpConfig.MachineImages, machineImages, field.NewPath("spec").Child("machineImages"))...)
+	allErrs = append(allErrs, validateRegionConfigs(cpConfig.RegionConfigs, fldPath.Child("regionConfigs"))...)
+	allErrs = append(allErrs, validateMachineTypes(cpConfig.MachineTypes, fldPath.Child("machineTypes"))...)
+	allErrs = append(allErrs, validateStorageClasses(cpConfig.StorageClasses, fldPath.Child("storageClasses"))...)
+
+	return allErrs
+}
+
+func validateRegionConfigs(regionConfigs []apismetal.RegionConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, regionConfig := range regionConfigs {
+		idxPath := fldPath.Index(i)
+		servers := sets.New(regionConfig.Server)
+		for j, server := range regionConfig.FailoverServers {
+			jdxPath := idxPath.Child("failoverServers").Index(j)
+			if u, err := url.Parse(server); err != nil || !slices.Contains([]string{"http", "https"}, u.Scheme) || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(jdxPath, server, "must be a valid http or https URL"))
+			}
+			if servers.Has(server) {
+				allErrs = append(allErrs, field.Duplicate(jdxPath, server))
+			}
+			servers.Insert(server)
+		}
+		if len(regionConfig.ProxyURL) > 0 {
+			if u, err := url.Parse(regionConfig.ProxyURL); err != nil || !slices.Contains([]string{"http", "https", "socks5"}, u.Scheme) || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("proxyURL"), regionConfig.ProxyURL, "must be a valid http, https or socks5 URL"))
+			}
+		}
+		if len(regionConfig.TLSServerName) > 0 && net.ParseIP(regionConfig.TLSServerName) == nil {
+			for _, msg := range validation.IsDNS1123Subdomain(regionConfig.TLSServerName) {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("tlsServerName"), regionConfig.TLSServerName, msg))
+			}
+		}
+		if len(regionConfig.AdditionalCertificateAuthorityData) > 0 {
+			// Without CertificateAuthorityData, the additional CAs would replace the system roots instead of extending them.
+			if len(regionConfig.CertificateAuthorityData) == 0 {
+				allErrs = append(allErrs, field.Required(idxPath.Child("certificateAuthorityData"), "must be set if additionalCertificateAuthorityData is set"))
+			}
+			if _, err := cert.ParseCertsPEM(regionConfig.AdditionalCertificateAuthorityData); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("additionalCertificateAuthorityData"), "(omitted)", fmt.Sprintf("must be a bundle of PEM encoded certificates: %v", err)))
+			}
+		}
+		for j, cidr := range regionConfig.EgressCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				allErrs = append(allErrs, field.Invalid(idxPath.Child("egressCIDRs").Index(j), cidr, "must be a valid CIDR"))
+			}
+		}
+		if regionConfig.Node != nil {
+			allErrs = append(allErrs, validateRegionNodeConfig(regionConfig.Node, idxPath.Child("node"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateRegionNodeConfig(node *apismetal.RegionNodeConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, server := range node.NTPServers {
+		if len(server) == 0 || strings.ContainsAny(server, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ntpServers").Index(i), server, "must be a non-empty host name or IP address"))
+		}
+	}
+	for i, server := range node.DNSServers {
+		if net.ParseIP(server) == nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("dnsServers").Index(i), server, "must be an IP address"))
+		}
+	}
+	if len(node.CABundle) > 0 {
+		if _, err := cert.ParseCertsPEM([]byte(node.CABundle)); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("caBundle"), "(omitted)", fmt.Sprintf("must be a bundle of PEM encoded certificates: %v", err)))
+		}
+	}
+
+	return allErrs
+}
+
+func validateMachineTypes(machineTypes []apismetal.MachineType, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, machineType := range machineTypes {
+		idxPath := fldPath.Index(i)
+		if len(machineType.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		}
+		for j, volume := range machineType.Volumes {
+			jdxPath := idxPath.Child("volumes").Index(j)
+			if len(volume.Type) == 0 {
+				allErrs = append(allErrs, field.Required(jdxPath.Child("type"), "must provide a type"))
+			}
+			if volume.Size.Sign() <= 0 {
+				allErrs = append(allErrs, field.Invalid(jdxPath.Child("size"), volume.Size.String(), "must be greater than zero"))
+			}
+		}
+		if machineType.Capacity != nil {
+			allErrs = append(allErrs, validateMachineTypeCapacity(machineType.Capacity, idxPath.Child("capacity"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateMachineTypeCapacity(capacity *apismetal.MachineTypeCapacity, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if capacity.CPU.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), capacity.CPU.String(), "must be greater than zero"))
+	}
+	if capacity.Memory.Sign() <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), capacity.Memory.String(), "must be greater than zero"))
+	}
+
+	hugePages := resource.Quantity{}
+	for size, quantity := range capacity.HugePages {
+		sizePath := fldPath.Child("hugePages").Key(size)
+		if pageSize, err := resource.ParseQuantity(size); err != nil || pageSize.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(sizePath, size, "must be a positive page size"))
+		}
+		if quantity.Sign() < 0 {
+			allErrs = append(allErrs, field.Invalid(sizePath, quantity.String(), "must not be negative"))
+		}
+		hugePages.Add(quantity)
+	}
+	if capacity.Memory.Sign() > 0 && hugePages.Cmp(capacity.Memory) >= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("hugePages"), hugePages.String(), "must be less than the memory"))
+	}
+
+	return allErrs
+}
+
+func validateStorageClasses(storageClasses apismetal.StorageClasses, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	names := sets.New[string]()
+	localTypes := sets.New[string]()
+
+	validate := func(sc apismetal.StorageClass, scPath *field.Path) {
+		if len(sc.Name) == 0 {
+			allErrs = append(allErrs, field.Required(scPath.Child("name"), "must provide a name"))
+		} else {
+			for _, msg := range validation.IsDNS1123Subdomain(sc.Name) {
+				allErrs = append(allErrs, field.Invalid(scPath.Child("name"), sc.Name, msg))
+			}
+			if names.Has(sc.Name) {
+				allErrs = append(allErrs, field.Duplicate(scPath.Child("name"), sc.Name))
+			}
+			names.Insert(sc.Name)
+		}
+
+		if len(sc.Type) == 0 {
+			allErrs = append(allErrs, field.Required(scPath.Child("type"), "must provide a type"))
+		} else {
+			for _, msg := range validation.IsDNS1123Label(sc.Type) {
+				allErrs = append(allErrs, field.Invalid(scPath.Child("type"), sc.Type, msg))
+			}
+		}
+
+		// only local static volumes are supported, other provisioners are neither deployed nor backed by prepared
+		// disks on the nodes
+		if sc.Provisioner != nil && *sc.Provisioner != metal.LocalStorageProvisioner {
+			allErrs = append(allErrs, field.NotSupported(scPath.Child("provisioner"), *sc.Provisioner, []string{metal.LocalStorageProvisioner}))
+		}
+		if localTypes.Has(sc.Type) {
+			allErrs = append(allErrs, field.Duplicate(scPath.Child("type"), sc.Type))
+		}
+		localTypes.Insert(sc.Type)
+
+		allErrs = append(allErrs, ValidateStorageClassModes(metal.LocalStorageProvisioner, sc.ReclaimPolicy, sc.VolumeBindingMode, scPath)...)
+	}
+
+	if storageClasses.Default != nil {
+		validate(*storageClasses.Default, fldPath.Child("default"))
+	}
+	for i, sc := range storageClasses.Additional {
+		validate(sc, fldPath.Child("additional").Index(i))
+	}
 
 	return allErrs
 }
@@ -9,7 +9,11 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/cert"
 	"k8s.io/utils/ptr"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
@@ -128,5 +132,295 @@ var _ = Describe("CloudProfileConfig validation", func() {
 			})
 		})
 
+		Describe("region config validation", func() {
+			It("should allow valid node settings", func() {
+				caBundle, _, err := cert.GenerateSelfSignedCertKey("registry.local", nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name: "foo",
+						Node: &apismetal.RegionNodeConfig{
+							NTPServers: []string{"ntp.local", "10.0.0.1"},
+							DNSServers: []string{"10.0.0.53", "2001:db8::53"},
+							LLDP:       true,
+							CABundle:   string(caBundle),
+						},
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid invalid node settings", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name: "foo",
+						Node: &apismetal.RegionNodeConfig{
+							NTPServers: []string{"", "ntp local"},
+							DNSServers: []string{"dns.local"},
+							CABundle:   "foo",
+						},
+					},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					InvalidField("regionConfigs[0].node.ntpServers[0]"),
+					InvalidField("regionConfigs[0].node.ntpServers[1]"),
+					InvalidField("regionConfigs[0].node.dnsServers[0]"),
+					InvalidField("regionConfigs[0].node.caBundle"),
+				))
+			})
+
+			It("should allow valid proxy and TLS settings", func() {
+				caData, _, err := cert.GenerateSelfSignedCertKey("egress.local", nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:                               "foo",
+						Server:                             "https://metal.foo.local",
+						ProxyURL:                           "http://proxy.local:3128",
+						TLSServerName:                      "metal-api.foo.local",
+						CertificateAuthorityData:           caData,
+						AdditionalCertificateAuthorityData: caData,
+					},
+					{
+						Name:          "bar",
+						Server:        "https://metal.bar.local",
+						ProxyURL:      "socks5://10.0.0.1:1080",
+						TLSServerName: "10.0.0.2",
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid invalid proxy and TLS settings", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:                               "foo",
+						Server:                             "https://metal.foo.local",
+						ProxyURL:                           "ftp://proxy.local",
+						TLSServerName:                      "metal api",
+						CertificateAuthorityData:           []byte("abcd12345"),
+						AdditionalCertificateAuthorityData: []byte("foo"),
+					},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					InvalidField("regionConfigs[0].proxyURL"),
+					InvalidField("regionConfigs[0].tlsServerName"),
+					InvalidField("regionConfigs[0].additionalCertificateAuthorityData"),
+				))
+			})
+
+			It("should require the CA data of the region server if additional CA data is set", func() {
+				caData, _, err := cert.GenerateSelfSignedCertKey("egress.local", nil, nil)
+				Expect(err).NotTo(HaveOccurred())
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:                               "foo",
+						Server:                             "https://metal.foo.local",
+						AdditionalCertificateAuthorityData: caData,
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(ConsistOf(
+					PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(field.ErrorTypeRequired),
+						"Field": Equal("regionConfigs[0].certificateAuthorityData"),
+					})),
+				))
+			})
+
+			It("should allow valid failover servers", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:            "foo",
+						Server:          "https://metal-a.foo.local",
+						FailoverServers: []string{"https://metal-b.foo.local", "https://10.0.0.3:6443"},
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid invalid and duplicate failover servers", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:            "foo",
+						Server:          "https://metal-a.foo.local",
+						FailoverServers: []string{"https://metal-a.foo.local", "ftp://metal-b.foo.local", "https://metal-c.foo.local", "https://metal-c.foo.local", "metal-d"},
+					},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeDuplicate, "regionConfigs[0].failoverServers[0]"),
+					InvalidField("regionConfigs[0].failoverServers[1]"),
+					SimpleMatchField(field.ErrorTypeDuplicate, "regionConfigs[0].failoverServers[3]"),
+					InvalidField("regionConfigs[0].failoverServers[4]"),
+				))
+			})
+
+			It("should allow valid egress CIDRs", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:        "foo",
+						Server:      "https://metal.foo.local",
+						EgressCIDRs: []string{"10.0.0.0/24", "2001:db8::/64"},
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid invalid egress CIDRs", func() {
+				cloudProfileConfig.RegionConfigs = []apismetal.RegionConfig{
+					{
+						Name:        "foo",
+						Server:      "https://metal.foo.local",
+						EgressCIDRs: []string{"10.0.0.0/24", "10.0.0.1", "foo"},
+					},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					InvalidField("regionConfigs[0].egressCIDRs[1]"),
+					InvalidField("regionConfigs[0].egressCIDRs[2]"),
+				))
+			})
+		})
+
+		Describe("machine type validation", func() {
+			It("should forbid machine type volumes without type and size", func() {
+				cloudProfileConfig.MachineTypes = []apismetal.MachineType{
+					{
+						Name: "large",
+						Volumes: []apismetal.MachineTypeVolume{
+							{Type: "nvme", Size: resource.MustParse("2Ti")},
+							{},
+						},
+					},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeRequired, "machineTypes[0].volumes[1].type"),
+					InvalidField("machineTypes[0].volumes[1].size"),
+				))
+			})
+
+			It("should allow a valid machine type capacity", func() {
+				cloudProfileConfig.MachineTypes = []apismetal.MachineType{
+					{
+						Name: "large",
+						Capacity: &apismetal.MachineTypeCapacity{
+							CPU:       resource.MustParse("64"),
+							Memory:    resource.MustParse("512Gi"),
+							HugePages: map[string]resource.Quantity{"2Mi": resource.MustParse("16Gi")},
+						},
+					},
+				}
+
+				Expect(ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)).To(BeEmpty())
+			})
+
+			It("should forbid invalid machine type capacities", func() {
+				cloudProfileConfig.MachineTypes = []apismetal.MachineType{
+					{
+						Name: "large",
+						Capacity: &apismetal.MachineTypeCapacity{
+							Memory: resource.MustParse("8Gi"),
+							HugePages: map[string]resource.Quantity{
+								"foo": resource.MustParse("1Gi"),
+								"1Gi": resource.MustParse("8Gi"),
+							},
+						},
+					},
+				}
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					InvalidField("machineTypes[0].capacity.cpu"),
+					InvalidField("machineTypes[0].capacity.hugePages[foo]"),
+					InvalidField("machineTypes[0].capacity.hugePages"),
+				))
+			})
+		})
+
+		Describe("storage class validation", func() {
+			BeforeEach(func() {
+				cloudProfileConfig.StorageClasses = apismetal.StorageClasses{
+					Default: &apismetal.StorageClass{
+						Name: "local-ssd",
+						Type: "ssd",
+					},
+					Additional: []apismetal.StorageClass{
+						{
+							Name:        "local-nvme",
+							Type:        "nvme",
+							Provisioner: ptr.To("kubernetes.io/no-provisioner"),
+						},
+					},
+				}
+			})
+
+			It("should pass validation", func() {
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(BeEmpty())
+			})
+
+			It("should forbid storage classes without name and type", func() {
+				cloudProfileConfig.StorageClasses.Additional = append(cloudProfileConfig.StorageClasses.Additional, apismetal.StorageClass{})
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeRequired, "storageClasses.additional[1].name"),
+					SimpleMatchField(field.ErrorTypeRequired, "storageClasses.additional[1].type"),
+				))
+			})
+
+			It("should forbid duplicate storage class names", func() {
+				cloudProfileConfig.StorageClasses.Additional[0].Name = "local-ssd"
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeDuplicate, "storageClasses.additional[0].name"),
+				))
+			})
+
+			It("should forbid unsupported provisioners", func() {
+				cloudProfileConfig.StorageClasses.Additional[0].Provisioner = ptr.To("topolvm.io")
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeNotSupported, "storageClasses.additional[0].provisioner"),
+				))
+			})
+
+			It("should forbid storage classes with the same type", func() {
+				cloudProfileConfig.StorageClasses.Additional[0].Type = "ssd"
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					SimpleMatchField(field.ErrorTypeDuplicate, "storageClasses.additional[0].type"),
+				))
+			})
+
+			It("should forbid unsupported reclaim policies and volume binding modes", func() {
+				cloudProfileConfig.StorageClasses.Default.VolumeBindingMode = ptr.To(storagev1.VolumeBindingImmediate)
+				cloudProfileConfig.StorageClasses.Additional[0].ReclaimPolicy = ptr.To(corev1.PersistentVolumeReclaimRecycle)
+				cloudProfileConfig.StorageClasses.Additional[0].VolumeBindingMode = ptr.To(storagev1.VolumeBindingMode("foo"))
+
+				errorList := ValidateCloudProfileConfig(cloudProfileConfig, machineImages, nilPath)
+				Expect(errorList).To(ConsistOf(
+					InvalidField("storageClasses.default.volumeBindingMode"),
+					SimpleMatchField(field.ErrorTypeNotSupported, "storageClasses.additional[0].reclaimPolicy"),
+					SimpleMatchField(field.ErrorTypeNotSupported, "storageClasses.additional[0].volumeBindingMode"),
+				))
+			})
+		})
 	})
 })
@@ -5,6 +5,8 @@ package validation
 
 import (
 	featurevalidation "github.com/gardener/gardener/pkg/utils/validation/features"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
@@ -19,14 +21,74 @@ func ValidateControlPlaneConfig(controlPlaneConfig *apismetal.ControlPlaneConfig
 		allErrs = append(allErrs, featurevalidation.ValidateFeatureGates(controlPlaneConfig.CloudControllerManager.FeatureGates, version, fldPath.Child("cloudControllerManager", metal.CloudControllerManagerFeatureGatesKeyName))...)
 	}
 
+	allErrs = append(allErrs, validateNodeNamePolicy(string(controlPlaneConfig.NodeNamePolicy), fldPath.Child("nodeNamePolicy"))...)
+
 	// TODO add validation for IPs
 
 	return allErrs
 }
 
+var supportedNodeNamePolicies = sets.New(
+	string(apismetal.NodeNamePolicyBMCName),
+	string(apismetal.NodeNamePolicyServerName),
+	string(apismetal.NodeNamePolicyServerClaimName),
+)
+
+func validateNodeNamePolicy(nodeNamePolicy string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if nodeNamePolicy != "" && !supportedNodeNamePolicies.Has(nodeNamePolicy) {
+		allErrs = append(allErrs, field.NotSupported(fldPath, nodeNamePolicy, sets.List(supportedNodeNamePolicies)))
+	}
+
+	return allErrs
+}
+
+// ValidateStorageConfig validates the StorageConfig of a ControlPlaneConfig against the StorageClasses
+// defined in the CloudProfileConfig.
+func ValidateStorageConfig(storage *apismetal.StorageConfig, storageClasses apismetal.StorageClasses, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if storage == nil {
+		return allErrs
+	}
+
+	available := sets.New[string]()
+	if storageClasses.Default != nil {
+		available.Insert(storageClasses.Default.Name)
+	}
+	for _, sc := range storageClasses.Additional {
+		available.Insert(sc.Name)
+	}
+
+	deployed := available
+	if len(storage.StorageClasses) > 0 {
+		deployed = sets.New[string]()
+		for i, name := range storage.StorageClasses {
+			idxPath := fldPath.Child("storageClasses").Index(i)
+			if !available.Has(name) {
+				allErrs = append(allErrs, field.NotSupported(idxPath, name, sets.List(available)))
+				continue
+			}
+			if deployed.Has(name) {
+				allErrs = append(allErrs, field.Duplicate(idxPath, name))
+			}
+			deployed.Insert(name)
+		}
+	}
+
+	if storage.DefaultStorageClass != nil && !deployed.Has(*storage.DefaultStorageClass) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("defaultStorageClass"), *storage.DefaultStorageClass, sets.List(deployed)))
+	}
+
+	return allErrs
+}
+
 // ValidateControlPlaneConfigUpdate validates a ControlPlaneConfig object.
 func ValidateControlPlaneConfigUpdate(oldConfig, newConfig *apismetal.ControlPlaneConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.NodeNamePolicy, oldConfig.NodeNamePolicy, fldPath.Child("nodeNamePolicy"))...)
+
 	return allErrs
 }
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 )
@@ -49,11 +50,83 @@ var _ = Describe("ControlPlaneConfig validation", func() {
 				})),
 			))
 		})
+
+		It("should fail with an unsupported node name policy", func() {
+			controlPlane.NodeNamePolicy = "Foo"
+
+			errorList := ValidateControlPlaneConfig(controlPlane, "", fldPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("nodeNamePolicy"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateStorageConfig", func() {
+		var storageClasses apismetal.StorageClasses
+
+		BeforeEach(func() {
+			storageClasses = apismetal.StorageClasses{
+				Default:    &apismetal.StorageClass{Name: "local-ssd", Type: "ssd"},
+				Additional: []apismetal.StorageClass{{Name: "local-hdd", Type: "hdd"}},
+			}
+		})
+
+		It("should return no errors if no storage is configured", func() {
+			Expect(ValidateStorageConfig(nil, storageClasses, fldPath)).To(BeEmpty())
+		})
+
+		It("should return no errors for a valid configuration", func() {
+			storage := &apismetal.StorageConfig{
+				StorageClasses:      []string{"local-hdd"},
+				DefaultStorageClass: ptr.To("local-hdd"),
+			}
+			Expect(ValidateStorageConfig(storage, storageClasses, fldPath)).To(BeEmpty())
+		})
+
+		It("should fail for unknown or duplicate storage classes", func() {
+			storage := &apismetal.StorageConfig{
+				StorageClasses:      []string{"local-hdd", "foo", "local-hdd"},
+				DefaultStorageClass: ptr.To("local-ssd"),
+			}
+
+			errorList := ValidateStorageConfig(storage, storageClasses, fldPath)
+
+			Expect(errorList).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("storageClasses[1]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeDuplicate),
+					"Field": Equal("storageClasses[2]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("defaultStorageClass"),
+				})),
+			))
+		})
 	})
 
 	Describe("#ValidateControlPlaneConfigUpdate", func() {
 		It("should return no errors for an unchanged config", func() {
 			Expect(ValidateControlPlaneConfigUpdate(controlPlane, controlPlane, fldPath)).To(BeEmpty())
 		})
+
+		It("should fail if the node name policy is changed", func() {
+			newControlPlane := controlPlane.DeepCopy()
+			newControlPlane.NodeNamePolicy = apismetal.NodeNamePolicyBMCName
+
+			Expect(ValidateControlPlaneConfigUpdate(controlPlane, newControlPlane, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("nodeNamePolicy"),
+				})),
+			))
+		})
 	})
 })
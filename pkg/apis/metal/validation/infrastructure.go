@@ -4,6 +4,11 @@
 package validation
 
 import (
+	"net/netip"
+	"strings"
+
+	"github.com/gardener/gardener/pkg/apis/core"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
@@ -11,10 +16,82 @@ import (
 
 // ValidateInfrastructureConfig validates a InfrastructureConfig object.
 func ValidateInfrastructureConfig(infra *apismetal.InfrastructureConfig, nodesCIDR, podsCIDR, servicesCIDR *string, fldPath *field.Path) field.ErrorList {
-	return field.ErrorList{}
+	allErrs := field.ErrorList{}
+
+	for i, network := range infra.Networks {
+		idxPath := fldPath.Child("networks").Index(i)
+		if len(network.Name) == 0 {
+			allErrs = append(allErrs, field.Required(idxPath.Child("name"), "must provide a name"))
+		}
+
+		families := sets.New[core.IPFamily]()
+		validateCIDR := func(cidr string, cidrPath *field.Path) {
+			family, err := cidrIPFamily(cidr)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(cidrPath, cidr, err.Error()))
+				return
+			}
+			if families.Has(family) {
+				allErrs = append(allErrs, field.Invalid(cidrPath, cidr, "a network must only have one CIDR per IP family"))
+			}
+			families.Insert(family)
+		}
+
+		validateCIDR(network.CIDR, idxPath.Child("cidr"))
+		for j, cidr := range network.AdditionalCIDRs {
+			validateCIDR(cidr, idxPath.Child("additionalCIDRs").Index(j))
+		}
+	}
+
+	return allErrs
 }
 
 // ValidateInfrastructureConfigUpdate validates a InfrastructureConfig object.
 func ValidateInfrastructureConfigUpdate(oldConfig, newConfig *apismetal.InfrastructureConfig, fldPath *field.Path) field.ErrorList {
 	return field.ErrorList{}
 }
+
+// networkCIDRs returns the CIDRs of all IP families of the given network.
+func networkCIDRs(network apismetal.Networks) []string {
+	return append([]string{network.CIDR}, network.AdditionalCIDRs...)
+}
+
+// cidrIPFamily returns the IP family of the given CIDR.
+func cidrIPFamily(cidr string) (core.IPFamily, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return "", err
+	}
+	return addrIPFamily(prefix.Addr()), nil
+}
+
+// addressPoolIPFamily returns the IP family of the given CIDR or IP range (`<start>-<end>`).
+func addressPoolIPFamily(pool string) (core.IPFamily, error) {
+	if start, _, ok := strings.Cut(pool, "-"); ok {
+		addr, err := netip.ParseAddr(strings.TrimSpace(start))
+		if err != nil {
+			return "", err
+		}
+		return addrIPFamily(addr), nil
+	}
+	return cidrIPFamily(pool)
+}
+
+// peerIPFamily returns the IP family of the given BGP peer address, which may contain a port.
+func peerIPFamily(peerIP string) (core.IPFamily, error) {
+	if addrPort, err := netip.ParseAddrPort(peerIP); err == nil {
+		return addrIPFamily(addrPort.Addr()), nil
+	}
+	addr, err := netip.ParseAddr(peerIP)
+	if err != nil {
+		return "", err
+	}
+	return addrIPFamily(addr), nil
+}
+
+func addrIPFamily(addr netip.Addr) core.IPFamily {
+	if addr.Unmap().Is4() {
+		return core.IPFamilyIPv4
+	}
+	return core.IPFamilyIPv6
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+var _ = Describe("InfrastructureConfig validation", func() {
+	var (
+		infraConfig *apismetal.InfrastructureConfig
+		fldPath     *field.Path
+	)
+
+	BeforeEach(func() {
+		infraConfig = &apismetal.InfrastructureConfig{
+			Networks: []apismetal.Networks{{
+				Name:            "foo",
+				CIDR:            "10.0.0.0/24",
+				AdditionalCIDRs: []string{"2001:db8::/64"},
+			}},
+		}
+	})
+
+	Describe("#ValidateInfrastructureConfig", func() {
+		It("should return no errors for a valid configuration", func() {
+			Expect(ValidateInfrastructureConfig(infraConfig, nil, nil, nil, fldPath)).To(BeEmpty())
+		})
+
+		It("should return an error if the network has no name", func() {
+			infraConfig.Networks[0].Name = ""
+
+			Expect(ValidateInfrastructureConfig(infraConfig, nil, nil, nil, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("networks[0].name"),
+				})),
+			))
+		})
+
+		It("should return an error for an invalid CIDR", func() {
+			infraConfig.Networks[0].AdditionalCIDRs = []string{"foo"}
+
+			Expect(ValidateInfrastructureConfig(infraConfig, nil, nil, nil, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks[0].additionalCIDRs[0]"),
+				})),
+			))
+		})
+
+		It("should return an error if a network has several CIDRs of the same IP family", func() {
+			infraConfig.Networks[0].AdditionalCIDRs = []string{"10.0.1.0/24"}
+
+			Expect(ValidateInfrastructureConfig(infraConfig, nil, nil, nil, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("networks[0].additionalCIDRs[0]"),
+				})),
+			))
+		})
+	})
+})
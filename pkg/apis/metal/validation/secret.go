@@ -4,30 +4,97 @@
 package validation
 
 import (
+	"crypto/tls"
 	"fmt"
+	"net/url"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/client-go/util/cert"
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
-// ValidateCloudProviderSecret checks whether the given secret contains a valid metal service account.
+var (
+	tokenFields             = []string{metal.TokenFieldName, metal.UsernameFieldName}
+	clientCertificateFields = []string{metal.ClientCertificateFieldName, metal.ClientKeyFieldName}
+	oidcFields              = []string{metal.OIDCIssuerURLFieldName, metal.OIDCClientIDFieldName, metal.OIDCRefreshTokenFieldName}
+	oidcOptionalFields      = []string{metal.OIDCClientSecretFieldName, metal.OIDCCertificateAuthorityDataFieldName}
+)
+
+// ValidateCloudProviderSecret checks whether the given secret contains a valid metal service account, a client
+// certificate or OIDC credentials.
+//
+// The kubeconfig built from OIDC credentials is static: the oidc auth provider of client-go cannot persist ID tokens
+// or refresh tokens rotated by the issuer into the secret. Hence, ID tokens are rejected, and the issuer has to issue
+// refresh tokens which stay valid when they are used.
 func ValidateCloudProviderSecret(secret *corev1.Secret) error {
-	if _, ok := secret.Data[metal.TokenFieldName]; !ok {
-		return fmt.Errorf("missing field: %s in cloud provider secret", metal.TokenFieldName)
-	}
 	namespace, ok := secret.Data[metal.NamespaceFieldName]
 	if !ok {
 		return fmt.Errorf("missing field: %s in cloud provider secret", metal.NamespaceFieldName)
 	}
-	if _, ok := secret.Data[metal.UsernameFieldName]; !ok {
-		return fmt.Errorf("missing field: %s in cloud provider secret", metal.UsernameFieldName)
-	}
 	errs := apivalidation.ValidateNamespaceName(string(namespace), false)
 	if len(errs) > 0 {
 		return fmt.Errorf("invalid field: %s in cloud provider secret", metal.NamespaceFieldName)
 	}
 
+	if hasAnyField(secret, metal.OIDCIDTokenFieldName) {
+		return fmt.Errorf("invalid field: %s in cloud provider secret: ID tokens expire and are obtained with the %s instead",
+			metal.OIDCIDTokenFieldName, metal.OIDCRefreshTokenFieldName)
+	}
+
+	hasToken := hasAnyField(secret, metal.TokenFieldName)
+	hasClientCertificate := hasAnyField(secret, clientCertificateFields...)
+	hasOIDC := hasAnyField(secret, append(oidcFields, oidcOptionalFields...)...)
+
+	switch {
+	case hasToken && !hasClientCertificate && !hasOIDC:
+		return requireFields(secret, tokenFields...)
+	case hasClientCertificate && !hasToken && !hasOIDC:
+		if err := requireFields(secret, clientCertificateFields...); err != nil {
+			return err
+		}
+		if _, err := tls.X509KeyPair(secret.Data[metal.ClientCertificateFieldName], secret.Data[metal.ClientKeyFieldName]); err != nil {
+			return fmt.Errorf("invalid fields: %s and %s in cloud provider secret: %w", metal.ClientCertificateFieldName, metal.ClientKeyFieldName, err)
+		}
+		return nil
+	case hasOIDC && !hasToken && !hasClientCertificate:
+		if err := requireFields(secret, oidcFields...); err != nil {
+			return err
+		}
+		if u, err := url.Parse(string(secret.Data[metal.OIDCIssuerURLFieldName])); err != nil || u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("invalid field: %s in cloud provider secret: must be a valid https URL", metal.OIDCIssuerURLFieldName)
+		}
+		if caData, ok := secret.Data[metal.OIDCCertificateAuthorityDataFieldName]; ok {
+			if _, err := cert.ParseCertsPEM(caData); err != nil {
+				return fmt.Errorf("invalid field: %s in cloud provider secret: %w", metal.OIDCCertificateAuthorityDataFieldName, err)
+			}
+		}
+		return nil
+	case !hasToken && !hasClientCertificate && !hasOIDC:
+		return fmt.Errorf("missing credentials in cloud provider secret: either %s, %s or %s must be provided",
+			strings.Join(tokenFields, "/"), strings.Join(clientCertificateFields, "/"), strings.Join(oidcFields, "/"))
+	default:
+		return fmt.Errorf("mixed credentials in cloud provider secret: only one of %s, %s or %s must be provided",
+			strings.Join(tokenFields, "/"), strings.Join(clientCertificateFields, "/"), strings.Join(oidcFields, "/"))
+	}
+}
+
+func hasAnyField(secret *corev1.Secret, fields ...string) bool {
+	for _, field := range fields {
+		if _, ok := secret.Data[field]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func requireFields(secret *corev1.Secret, fields ...string) error {
+	for _, field := range fields {
+		if len(secret.Data[field]) == 0 {
+			return fmt.Errorf("missing field: %s in cloud provider secret", field)
+		}
+	}
 	return nil
 }
@@ -8,9 +8,19 @@ import (
 	. "github.com/onsi/gomega"
 	gomegatypes "github.com/onsi/gomega/types"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/cert"
 )
 
 var _ = Describe("Secret validation", func() {
+	clientCertificate, clientKey, err := cert.GenerateSelfSignedCertKey("metal-user", nil, nil)
+	if err != nil {
+		panic(err)
+	}
+	_, otherClientKey, err := cert.GenerateSelfSignedCertKey("other-user", nil, nil)
+	if err != nil {
+		panic(err)
+	}
+
 	DescribeTable("#ValidateCloudProviderSecret",
 		func(data map[string][]byte, matcher gomegatypes.GomegaMatcher) {
 			secret := &corev1.Secret{
@@ -47,5 +57,81 @@ var _ = Describe("Secret validation", func() {
 				"username":  []byte("admin"),
 			},
 			Not(HaveOccurred())),
+		Entry("should return an error if the secret contains no credentials",
+			map[string][]byte{
+				"namespace": []byte("foo"),
+			}, MatchError(ContainSubstring("missing credentials"))),
+		Entry("should return no error if the secret contains a valid client certificate",
+			map[string][]byte{
+				"namespace": []byte("foo"),
+				"tls.crt":   clientCertificate,
+				"tls.key":   clientKey,
+			},
+			Not(HaveOccurred())),
+		Entry("should return an error if the client key is missing",
+			map[string][]byte{
+				"namespace": []byte("foo"),
+				"tls.crt":   clientCertificate,
+			}, MatchError("missing field: tls.key in cloud provider secret")),
+		Entry("should return an error if the client key does not match the certificate",
+			map[string][]byte{
+				"namespace": []byte("foo"),
+				"tls.crt":   clientCertificate,
+				"tls.key":   otherClientKey,
+			}, MatchError(ContainSubstring("invalid fields: tls.crt and tls.key"))),
+		Entry("should return no error if the secret contains valid OIDC credentials",
+			map[string][]byte{
+				"namespace":                      []byte("foo"),
+				"idp-issuer-url":                 []byte("https://issuer.example.com"),
+				"client-id":                      []byte("metal"),
+				"client-secret":                  []byte("secret"),
+				"refresh-token":                  []byte("refresh"),
+				"idp-certificate-authority-data": clientCertificate,
+			},
+			Not(HaveOccurred())),
+		Entry("should return an error if the OIDC refresh token is missing",
+			map[string][]byte{
+				"namespace":      []byte("foo"),
+				"idp-issuer-url": []byte("https://issuer.example.com"),
+				"client-id":      []byte("metal"),
+			}, MatchError("missing field: refresh-token in cloud provider secret")),
+		Entry("should return an error if the secret contains an OIDC ID token",
+			map[string][]byte{
+				"namespace":      []byte("foo"),
+				"idp-issuer-url": []byte("https://issuer.example.com"),
+				"client-id":      []byte("metal"),
+				"refresh-token":  []byte("refresh"),
+				"id-token":       []byte("id"),
+			}, MatchError(ContainSubstring("invalid field: id-token"))),
+		Entry("should return an error if the OIDC issuer URL is not https",
+			map[string][]byte{
+				"namespace":      []byte("foo"),
+				"idp-issuer-url": []byte("http://issuer.example.com"),
+				"client-id":      []byte("metal"),
+				"refresh-token":  []byte("refresh"),
+			}, MatchError(ContainSubstring("invalid field: idp-issuer-url"))),
+		Entry("should return an error if the OIDC CA bundle is invalid",
+			map[string][]byte{
+				"namespace":                      []byte("foo"),
+				"idp-issuer-url":                 []byte("https://issuer.example.com"),
+				"client-id":                      []byte("metal"),
+				"refresh-token":                  []byte("refresh"),
+				"idp-certificate-authority-data": []byte("foo"),
+			}, MatchError(ContainSubstring("invalid field: idp-certificate-authority-data"))),
+		Entry("should return an error if the secret contains a token and a client certificate",
+			map[string][]byte{
+				"namespace": []byte("foo"),
+				"token":     []byte("foo"),
+				"username":  []byte("admin"),
+				"tls.crt":   clientCertificate,
+				"tls.key":   clientKey,
+			}, MatchError(ContainSubstring("mixed credentials"))),
+		Entry("should return an error if the secret contains a client certificate and OIDC credentials",
+			map[string][]byte{
+				"namespace":     []byte("foo"),
+				"tls.crt":       clientCertificate,
+				"tls.key":       clientKey,
+				"client-secret": []byte("secret"),
+			}, MatchError(ContainSubstring("mixed credentials"))),
 	)
 })
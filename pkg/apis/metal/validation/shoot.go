@@ -5,15 +5,23 @@ package validation
 
 import (
 	"encoding/json"
+	"fmt"
 	"slices"
 
 	"github.com/gardener/gardener/pkg/apis/core"
 	"github.com/gardener/gardener/pkg/apis/core/helper"
 	validationutils "github.com/gardener/gardener/pkg/utils/validation"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/butane"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	metalhelper "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
 // ValidateNetworking validates the network settings of a Shoot.
@@ -30,6 +38,7 @@ func ValidateNetworking(networking *core.Networking, fldPath *field.Path) field.
 // ValidateWorkers validates the workers of a Shoot.
 func ValidateWorkers(workers []core.Worker, fldPath *field.Path, shootSpec *core.ShootSpec) field.ErrorList {
 	allErrs := field.ErrorList{}
+	templateVariables := exampleTemplateVariables(shootSpec)
 
 	for i, worker := range workers {
 		workerFldPath := fldPath.Index(i)
@@ -51,21 +60,116 @@ func ValidateWorkers(workers []core.Worker, fldPath *field.Path, shootSpec *core
 				allErrs = append(allErrs, field.Invalid(workerFldPath.Child("providerConfig"), worker.ProviderConfig.Raw, "could not unmarshal worker provider config"))
 			}
 
-			if workerConfig.ExtraIgnition != nil && workerConfig.ExtraIgnition.SecretRef != "" {
-				contained := slices.ContainsFunc(shootSpec.Resources, func(resource core.NamedResourceReference) bool {
-					return resource.Name == workerConfig.ExtraIgnition.SecretRef
-				})
-				if !contained {
-					allErrs = append(allErrs, field.Invalid(workerFldPath.Child("providerConfig").Child("extraIgnition").Child("secretRef"), workerConfig.ExtraIgnition.SecretRef, "secretRef must reference a secret in the shoot's resources"))
+			if workerConfig.ExtraIgnition != nil {
+				extraIgnitionFldPath := workerFldPath.Child("providerConfig").Child("extraIgnition")
+				if workerConfig.ExtraIgnition.SecretRef != "" && !containsResource(shootSpec.Resources, workerConfig.ExtraIgnition.SecretRef) {
+					allErrs = append(allErrs, field.Invalid(extraIgnitionFldPath.Child("secretRef"), workerConfig.ExtraIgnition.SecretRef, "secretRef must reference a secret in the shoot's resources"))
 				}
+				for j, secretRef := range workerConfig.ExtraIgnition.SecretRefs {
+					if !containsResource(shootSpec.Resources, secretRef) {
+						allErrs = append(allErrs, field.Invalid(extraIgnitionFldPath.Child("secretRefs").Index(j), secretRef, "secretRef must reference a secret in the shoot's resources"))
+					}
+				}
+				allErrs = append(allErrs, validateIgnitionConfig(workerConfig.ExtraIgnition, workerConfig.RenderTemplates, templateVariables, extraIgnitionFldPath)...)
+			}
+
+			allErrs = append(allErrs, validateNodeNamePolicy(string(workerConfig.NodeNamePolicy), workerFldPath.Child("providerConfig", "nodeNamePolicy"))...)
+
+			if workerConfig.RenderTemplates {
+				for key, value := range workerConfig.Metadata {
+					if _, err := metalhelper.RenderTemplate(value, templateVariables); err != nil {
+						allErrs = append(allErrs, field.Invalid(workerFldPath.Child("providerConfig", "metadata").Key(key), value, err.Error()))
+					}
+				}
+			}
+		}
+
+	}
+
+	return allErrs
+}
+
+var supportedIgnitionFormats = sets.New(
+	string(metalv1alpha1.IgnitionFormatIgnition),
+	string(metalv1alpha1.IgnitionFormatButane),
+)
+
+// exampleTemplateVariables returns template variables with placeholder values and the networks of the
+// InfrastructureConfig of the given Shoot, which are used to reject templates referencing unknown variables.
+func exampleTemplateVariables(shootSpec *core.ShootSpec) *metalhelper.TemplateVariables {
+	templateVariables := metalhelper.ExampleTemplateVariables()
+
+	if shootSpec.Provider.InfrastructureConfig != nil {
+		infrastructureConfig := &metalv1alpha1.InfrastructureConfig{}
+		// errors are reported by the InfrastructureConfig validation
+		if err := json.Unmarshal(shootSpec.Provider.InfrastructureConfig.Raw, infrastructureConfig); err == nil {
+			for _, network := range infrastructureConfig.Networks {
+				templateVariables.Networks[network.Name] = network.CIDR
 			}
 		}
+	}
+
+	return templateVariables
+}
+
+func validateIgnitionConfig(ignitionConfig *metalv1alpha1.IgnitionConfig, renderTemplates bool, templateVariables *metalhelper.TemplateVariables, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if ignitionConfig.Format != "" && !supportedIgnitionFormats.Has(string(ignitionConfig.Format)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("format"), ignitionConfig.Format, sets.List(supportedIgnitionFormats)))
+		return allErrs
+	}
+
+	if ignitionConfig.Raw == "" {
+		return allErrs
+	}
+
+	raw := ignitionConfig.Raw
+	if renderTemplates {
+		var err error
+		if raw, err = metalhelper.RenderTemplate(raw, templateVariables); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("raw"), ignitionConfig.Raw, err.Error()))
+			return allErrs
+		}
+	}
+
+	if ignitionConfig.Format == metalv1alpha1.IgnitionFormatButane {
+		_, report, _ := butane.Translate([]byte(raw))
+		for _, entry := range report.Entries {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("raw"), ignitionConfig.Raw, fmt.Sprintf("could not translate butane config: %s", entry)))
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateIgnitionSecret validates that the given secret referenced by an extra ignition config contains an
+// ignition config of the given format.
+func ValidateIgnitionSecret(secret *corev1.Secret, format metalv1alpha1.IgnitionFormat, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
 
+	content, ok := secret.Data[metal.IgnitionFieldName]
+	if !ok {
+		allErrs = append(allErrs, field.Invalid(fldPath, secret.Name, fmt.Sprintf("referenced secret does not contain the %s key", metal.IgnitionFieldName)))
+		return allErrs
+	}
+
+	if format == metalv1alpha1.IgnitionFormatButane {
+		_, report, _ := butane.Translate(content)
+		for _, entry := range report.Entries {
+			allErrs = append(allErrs, field.Invalid(fldPath, secret.Name, fmt.Sprintf("could not translate butane config of referenced secret: %s", entry)))
+		}
 	}
 
 	return allErrs
 }
 
+func containsResource(resources []core.NamedResourceReference, name string) bool {
+	return slices.ContainsFunc(resources, func(resource core.NamedResourceReference) bool {
+		return resource.Name == name
+	})
+}
+
 func validateVolume(vol *core.Volume, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if vol.Type == nil {
@@ -73,10 +177,32 @@ func validateVolume(vol *core.Volume, fldPath *field.Path) field.ErrorList {
 	}
 	if vol.VolumeSize == "" {
 		allErrs = append(allErrs, field.Required(fldPath.Child("size"), "must not be empty"))
+	} else if _, err := resource.ParseQuantity(vol.VolumeSize); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("size"), vol.VolumeSize, err.Error()))
 	}
 	return allErrs
 }
 
+// ValidateWorkerVolumes validates that the volumes of the workers are offered by their machine types.
+func ValidateWorkerVolumes(workers []core.Worker, cloudProfileConfig *apismetal.CloudProfileConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, worker := range workers {
+		if worker.Volume == nil || worker.Volume.Type == nil {
+			continue
+		}
+		size, err := resource.ParseQuantity(worker.Volume.VolumeSize)
+		if err != nil {
+			continue
+		}
+		if _, err := metalhelper.FindMachineTypeVolume(cloudProfileConfig, worker.Machine.Type, *worker.Volume.Type, size); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i).Child("volume"), worker.Volume, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateWorkersUpdate validates updates on Workers.
 func ValidateWorkersUpdate(oldWorkers, newWorkers []core.Worker, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
@@ -87,6 +213,88 @@ func ValidateWorkersUpdate(oldWorkers, newWorkers []core.Worker, fldPath *field.
 		if oldWorker != nil && validationutils.ShouldEnforceImmutability(newWorker.Zones, oldWorker.Zones) {
 			allErrs = append(allErrs, apivalidation.ValidateImmutableField(newWorker.Zones, oldWorker.Zones, workerFldPath.Child("zones"))...)
 		}
+
+		if oldWorker != nil {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(workerNodeNamePolicy(newWorker), workerNodeNamePolicy(*oldWorker), workerFldPath.Child("providerConfig", "nodeNamePolicy"))...)
+		}
+	}
+	return allErrs
+}
+
+// workerNodeNamePolicy returns the NodeNamePolicy of the WorkerConfig of the given worker. Invalid provider configs
+// are reported by the worker validation.
+func workerNodeNamePolicy(worker core.Worker) metalv1alpha1.NodeNamePolicy {
+	if worker.ProviderConfig == nil {
+		return ""
+	}
+	workerConfig := &metalv1alpha1.WorkerConfig{}
+	if err := json.Unmarshal(worker.ProviderConfig.Raw, workerConfig); err != nil {
+		return ""
+	}
+	return workerConfig.NodeNamePolicy
+}
+
+// ValidateIPFamilies validates that the networks of the InfrastructureConfig and the load balancer addresses of the
+// ControlPlaneConfig are consistent with the IP families of the Shoot.
+func ValidateIPFamilies(networking *core.Networking, infra *apismetal.InfrastructureConfig, cpConfig *apismetal.ControlPlaneConfig, infraPath, cpPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	ipFamilies := sets.New(core.IPFamilyIPv4)
+	if networking != nil && len(networking.IPFamilies) > 0 {
+		ipFamilies = sets.New(networking.IPFamilies...)
 	}
+
+	if infra != nil {
+		for i, network := range infra.Networks {
+			families := sets.New[core.IPFamily]()
+			for _, cidr := range networkCIDRs(network) {
+				if family, err := cidrIPFamily(cidr); err == nil {
+					families.Insert(family)
+				}
+			}
+			if !families.Equal(ipFamilies) {
+				allErrs = append(allErrs, field.Invalid(infraPath.Child("networks").Index(i), network.Name,
+					fmt.Sprintf("network must have exactly one CIDR for each IP family of the shoot %v", sets.List(ipFamilies))))
+			}
+		}
+	}
+
+	if cpConfig == nil || cpConfig.LoadBalancerConfig == nil {
+		return allErrs
+	}
+
+	validateFamily := func(value string, family func(string) (core.IPFamily, error), fldPath *field.Path) {
+		f, err := family(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, err.Error()))
+			return
+		}
+		if !ipFamilies.Has(f) {
+			allErrs = append(allErrs, field.Invalid(fldPath, value, fmt.Sprintf("IP family %s is not enabled for the shoot", f)))
+		}
+	}
+
+	lbPath := cpPath.Child("loadBalancerConfig")
+	if metallb := cpConfig.LoadBalancerConfig.MetallbConfig; metallb != nil {
+		for i, pool := range metallb.IPAddressPool {
+			validateFamily(pool, addressPoolIPFamily, lbPath.Child("metallbConfig", "ipAddressPool").Index(i))
+		}
+	}
+	if calico := cpConfig.LoadBalancerConfig.CalicoBgpConfig; calico != nil {
+		calicoPath := lbPath.Child("calicoBgpConfig")
+		for i, cidr := range calico.ServiceLoadBalancerIPs {
+			validateFamily(cidr, addressPoolIPFamily, calicoPath.Child("serviceLoadBalancerIPs").Index(i))
+		}
+		for i, cidr := range calico.ServiceExternalIPs {
+			validateFamily(cidr, addressPoolIPFamily, calicoPath.Child("serviceExternalIPs").Index(i))
+		}
+		for i, cidr := range calico.ServiceClusterIPs {
+			validateFamily(cidr, addressPoolIPFamily, calicoPath.Child("serviceClusterIPs").Index(i))
+		}
+		for i, peer := range calico.BgpPeer {
+			validateFamily(peer.PeerIP, peerIPFamily, calicoPath.Child("bgpPeer").Index(i).Child("peerIP"))
+		}
+	}
+
 	return allErrs
 }
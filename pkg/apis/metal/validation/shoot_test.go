@@ -8,9 +8,15 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/ptr"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 )
 
 var _ = Describe("ShootConfig validation", func() {
@@ -52,6 +58,445 @@ var _ = Describe("ShootConfig validation", func() {
 			))
 		})
 
+		It("should return an error if an extra ignition secretRefs entry is not in the shoot's resources", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"extraIgnition": {"secretRefs": ["some-secret", "other-secret"]}}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Name:       ptr.To("volume"),
+						Type:       ptr.To("persistentDisk"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			shootSpec := &core.ShootSpec{
+				Resources: []core.NamedResourceReference{{Name: "some-secret"}},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, shootSpec)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].providerConfig.extraIgnition.secretRefs[1]"),
+				})),
+			))
+		})
+
+		It("should return an error for an unsupported extra ignition format", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"extraIgnition": {"format": "cloud-config"}}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("[0].providerConfig.extraIgnition.format"),
+				})),
+			))
+		})
+
+		It("should return an error if the raw butane config cannot be translated", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"extraIgnition": {"format": "butane", "raw": "variant: flatcar\nversion: 0.1.0\n"}}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("[0].providerConfig.extraIgnition.raw"),
+					"Detail": ContainSubstring("unsupported butane version"),
+				})),
+			))
+
+			workerConfig[0].ProviderConfig.Raw = []byte(`{"extraIgnition": {"format": "butane", "raw": "variant: flatcar\nversion: 1.0.0\n"}}`)
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(BeEmpty())
+		})
+
+		It("should return an error for templates referencing unknown variables", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"renderTemplates": true, "extraIgnition": {"raw": "zone: {{ .Zone }}\nfoo: {{ .Foo }}\n"}, "metadata": {"zone": "{{ .Zone }}", "foo": "{{ .Foo }}", "cidr": "{{ .Networks.workers }}", "index": "{{ index .Networks \"workers\" }}"}}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			shootSpec := &core.ShootSpec{
+				Provider: core.Provider{
+					InfrastructureConfig: &runtime.RawExtension{
+						Raw: []byte(`{"networks": [{"name": "workers", "cidr": "10.0.0.0/24"}]}`),
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, shootSpec)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].providerConfig.extraIgnition.raw"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].providerConfig.metadata[foo]"),
+				})),
+			))
+		})
+
+		It("should return an error for templates using functions or control structures", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"renderTemplates": true, "metadata": {"printf": "{{ printf \"%s\" .Zone }}", "call": "{{ call .Zone }}", "if": "{{ if .Zone }}zone{{ end }}", "pipe": "{{ .Zone | js }}", "variable": "{{ $zone := .Zone }}"}}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("[0].providerConfig.metadata[printf]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("[0].providerConfig.metadata[call]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("[0].providerConfig.metadata[if]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("[0].providerConfig.metadata[pipe]")})),
+				PointTo(MatchFields(IgnoreExtras, Fields{"Field": Equal("[0].providerConfig.metadata[variable]")})),
+			))
+		})
+
+		It("should not render templates unless opted in", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"extraIgnition": {"raw": "foo: '{{ .Foo }}'\n"}, "metadata": {"foo": "{{ .Foo }}"}}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(BeEmpty())
+		})
+
+		It("should return an error for an unsupported node name policy", func() {
+			workerConfig = []core.Worker{
+				{
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"nodeNamePolicy": "Foo"}`),
+					},
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "10Gi",
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeNotSupported),
+					"Field": Equal("[0].providerConfig.nodeNamePolicy"),
+				})),
+			))
+		})
+
+		It("should return an error if the volume size is not a quantity", func() {
+			workerConfig = []core.Worker{
+				{
+					Zones: []string{"zone"},
+					Volume: &core.Volume{
+						Type:       ptr.To("fast"),
+						VolumeSize: "foo",
+					},
+				},
+			}
+			Expect(ValidateWorkers(workerConfig, fldPath, &core.ShootSpec{})).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].volume.size"),
+				})),
+			))
+		})
 	})
 
+	Describe("#ValidateWorkerVolumes", func() {
+		var (
+			cloudProfileConfig *apismetal.CloudProfileConfig
+			workers            []core.Worker
+			fldPath            *field.Path
+		)
+
+		BeforeEach(func() {
+			cloudProfileConfig = &apismetal.CloudProfileConfig{
+				MachineTypes: []apismetal.MachineType{
+					{
+						Name: "large",
+						Volumes: []apismetal.MachineTypeVolume{
+							{Type: "nvme", Size: resource.MustParse("2Ti")},
+							{Type: "sata", Size: resource.MustParse("240G")},
+						},
+					},
+					{
+						Name: "small",
+					},
+				},
+			}
+			workers = []core.Worker{
+				{
+					Machine: core.Machine{Type: "large"},
+					Volume: &core.Volume{
+						Type:       ptr.To("nvme"),
+						VolumeSize: "2Ti",
+					},
+				},
+				{
+					Machine: core.Machine{Type: "small"},
+					Volume: &core.Volume{
+						Type:       ptr.To("sata"),
+						VolumeSize: "10Ti",
+					},
+				},
+			}
+		})
+
+		It("should return no errors if the volumes are offered by the machine types", func() {
+			Expect(ValidateWorkerVolumes(workers, cloudProfileConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should return an error if the volume is larger than offered by the machine type", func() {
+			workers[0].Volume.VolumeSize = "4Ti"
+			Expect(ValidateWorkerVolumes(workers, cloudProfileConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].volume"),
+				})),
+			))
+		})
+
+		It("should return an error if the volume type is not offered by the machine type", func() {
+			workers[0].Volume.Type = ptr.To("hdd")
+			Expect(ValidateWorkerVolumes(workers, cloudProfileConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].volume"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateWorkersUpdate", func() {
+		var (
+			oldWorkers []core.Worker
+			fldPath    *field.Path
+		)
+
+		BeforeEach(func() {
+			oldWorkers = []core.Worker{
+				{
+					Name:  "pool",
+					Zones: []string{"zone"},
+					ProviderConfig: &runtime.RawExtension{
+						Raw: []byte(`{"nodeNamePolicy": "ServerClaimName"}`),
+					},
+				},
+			}
+		})
+
+		It("should return no errors for unchanged workers", func() {
+			Expect(ValidateWorkersUpdate(oldWorkers, oldWorkers, fldPath)).To(BeEmpty())
+		})
+
+		It("should return an error if the node name policy of a worker is changed", func() {
+			newWorkers := []core.Worker{*oldWorkers[0].DeepCopy()}
+			newWorkers[0].ProviderConfig.Raw = []byte(`{"nodeNamePolicy": "BMCName"}`)
+
+			Expect(ValidateWorkersUpdate(oldWorkers, newWorkers, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("[0].providerConfig.nodeNamePolicy"),
+				})),
+			))
+		})
+
+		It("should allow a node name policy for new workers", func() {
+			newWorkers := append(oldWorkers, core.Worker{
+				Name:  "new",
+				Zones: []string{"zone"},
+				ProviderConfig: &runtime.RawExtension{
+					Raw: []byte(`{"nodeNamePolicy": "BMCName"}`),
+				},
+			})
+
+			Expect(ValidateWorkersUpdate(oldWorkers, newWorkers, fldPath)).To(BeEmpty())
+		})
+	})
+
+	Describe("#ValidateIPFamilies", func() {
+
+		var (
+			networking  *core.Networking
+			infraConfig *apismetal.InfrastructureConfig
+			cpConfig    *apismetal.ControlPlaneConfig
+			infraPath   = field.NewPath("infrastructureConfig")
+			cpPath      = field.NewPath("controlPlaneConfig")
+		)
+
+		BeforeEach(func() {
+			networking = &core.Networking{
+				IPFamilies: []core.IPFamily{core.IPFamilyIPv4, core.IPFamilyIPv6},
+			}
+			infraConfig = &apismetal.InfrastructureConfig{
+				Networks: []apismetal.Networks{{
+					Name:            "foo",
+					CIDR:            "10.0.0.0/24",
+					AdditionalCIDRs: []string{"2001:db8::/64"},
+				}},
+			}
+			cpConfig = &apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{
+					MetallbConfig: &apismetal.MetallbConfig{
+						IPAddressPool: []string{"10.10.10.0/24", "2001:db8:1::1-2001:db8:1::ff"},
+					},
+					CalicoBgpConfig: &apismetal.CalicoBgpConfig{
+						ServiceLoadBalancerIPs: []string{"10.10.10.0/24", "2001:db8:1::/112"},
+						BgpPeer: []apismetal.BgpPeer{
+							{PeerIP: "10.0.0.1"},
+							{PeerIP: "[2001:db8::1]:179"},
+						},
+					},
+				},
+			}
+		})
+
+		It("should return no errors for a consistent dual-stack configuration", func() {
+			Expect(ValidateIPFamilies(networking, infraConfig, cpConfig, infraPath, cpPath)).To(BeEmpty())
+		})
+
+		It("should accept address ranges for the calico service IPs", func() {
+			cpConfig.LoadBalancerConfig.CalicoBgpConfig.ServiceLoadBalancerIPs = []string{"10.20.20.10-10.20.20.30"}
+			cpConfig.LoadBalancerConfig.CalicoBgpConfig.ServiceExternalIPs = []string{"2001:db8:2::10-2001:db8:2::30"}
+			cpConfig.LoadBalancerConfig.CalicoBgpConfig.ServiceClusterIPs = []string{"10.30.0.0/16"}
+
+			Expect(ValidateIPFamilies(networking, infraConfig, cpConfig, infraPath, cpPath)).To(BeEmpty())
+		})
+
+		It("should return an error for calico service IP ranges of IP families not enabled for the shoot", func() {
+			networking.IPFamilies = []core.IPFamily{core.IPFamilyIPv6}
+			infraConfig.Networks[0].CIDR = "2001:db8::/64"
+			infraConfig.Networks[0].AdditionalCIDRs = nil
+			cpConfig.LoadBalancerConfig.MetallbConfig = nil
+			cpConfig.LoadBalancerConfig.CalicoBgpConfig = &apismetal.CalicoBgpConfig{
+				ServiceExternalIPs: []string{"10.20.20.10-10.20.20.30"},
+			}
+
+			Expect(ValidateIPFamilies(networking, infraConfig, cpConfig, infraPath, cpPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controlPlaneConfig.loadBalancerConfig.calicoBgpConfig.serviceExternalIPs[0]"),
+				})),
+			))
+		})
+
+		It("should default to IPv4 if the shoot does not configure IP families", func() {
+			networking.IPFamilies = nil
+			infraConfig.Networks[0].AdditionalCIDRs = nil
+			cpConfig.LoadBalancerConfig = nil
+
+			Expect(ValidateIPFamilies(networking, infraConfig, cpConfig, infraPath, cpPath)).To(BeEmpty())
+		})
+
+		It("should return an error if a network misses a CIDR of an IP family of the shoot", func() {
+			infraConfig.Networks[0].AdditionalCIDRs = nil
+
+			Expect(ValidateIPFamilies(networking, infraConfig, cpConfig, infraPath, cpPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("infrastructureConfig.networks[0]"),
+				})),
+			))
+		})
+
+		It("should return errors for load balancer addresses of IP families not enabled for the shoot", func() {
+			networking.IPFamilies = []core.IPFamily{core.IPFamilyIPv6}
+			infraConfig.Networks[0].CIDR = "2001:db8::/64"
+			infraConfig.Networks[0].AdditionalCIDRs = nil
+
+			Expect(ValidateIPFamilies(networking, infraConfig, cpConfig, infraPath, cpPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controlPlaneConfig.loadBalancerConfig.metallbConfig.ipAddressPool[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controlPlaneConfig.loadBalancerConfig.calicoBgpConfig.serviceLoadBalancerIPs[0]"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("controlPlaneConfig.loadBalancerConfig.calicoBgpConfig.bgpPeer[0].peerIP"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateIgnitionSecret", func() {
+		var (
+			secret  *corev1.Secret
+			fldPath *field.Path
+		)
+
+		BeforeEach(func() {
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "ref-ignition"},
+				Data: map[string][]byte{
+					"ignition": []byte("variant: flatcar\nversion: 1.0.0\n"),
+				},
+			}
+			fldPath = field.NewPath("secretRef")
+		})
+
+		It("should return no errors for a valid butane config", func() {
+			Expect(ValidateIgnitionSecret(secret, metalv1alpha1.IgnitionFormatButane, fldPath)).To(BeEmpty())
+		})
+
+		It("should return an error if the secret does not contain an ignition", func() {
+			delete(secret.Data, "ignition")
+			Expect(ValidateIgnitionSecret(secret, metalv1alpha1.IgnitionFormatButane, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("secretRef"),
+				})),
+			))
+		})
+
+		It("should return an error if the butane config cannot be translated", func() {
+			secret.Data["ignition"] = []byte("variant: foo\nversion: 1.0.0\n")
+			Expect(ValidateIgnitionSecret(secret, metalv1alpha1.IgnitionFormatButane, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(field.ErrorTypeInvalid),
+					"Field":  Equal("secretRef"),
+					"Detail": ContainSubstring("unsupported butane variant"),
+				})),
+			))
+		})
+	})
 })
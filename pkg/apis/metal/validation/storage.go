@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var (
+	supportedReclaimPolicies = sets.New(
+		string(corev1.PersistentVolumeReclaimDelete),
+		string(corev1.PersistentVolumeReclaimRetain),
+	)
+	supportedVolumeBindingModes = sets.New(
+		string(storagev1.VolumeBindingImmediate),
+		string(storagev1.VolumeBindingWaitForFirstConsumer),
+	)
+)
+
+// ValidateStorageClassModes validates the reclaim policy and volume binding mode of a StorageClass with the given
+// provisioner. StorageClasses of local static volumes require the volume binding mode WaitForFirstConsumer.
+func ValidateStorageClassModes(provisioner string, reclaimPolicy *corev1.PersistentVolumeReclaimPolicy, volumeBindingMode *storagev1.VolumeBindingMode, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if reclaimPolicy != nil && !supportedReclaimPolicies.Has(string(*reclaimPolicy)) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("reclaimPolicy"), *reclaimPolicy, sets.List(supportedReclaimPolicies)))
+	}
+
+	if volumeBindingMode != nil {
+		if !supportedVolumeBindingModes.Has(string(*volumeBindingMode)) {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("volumeBindingMode"), *volumeBindingMode, sets.List(supportedVolumeBindingModes)))
+		} else if provisioner == metal.LocalStorageProvisioner && *volumeBindingMode != storagev1.VolumeBindingWaitForFirstConsumer {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("volumeBindingMode"), *volumeBindingMode, "local static volumes require the volume binding mode WaitForFirstConsumer"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/url"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/cert"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+// ValidateWorkloadIdentityConfig validates a WorkloadIdentityConfig object.
+func ValidateWorkloadIdentityConfig(config *apismetal.WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(config.Namespace) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("namespace"), "must provide the namespace in the metal cluster"))
+	} else {
+		for _, msg := range apivalidation.ValidateNamespaceName(config.Namespace, false) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), config.Namespace, msg))
+		}
+	}
+
+	if tokenExchange := config.TokenExchange; tokenExchange != nil {
+		tokenExchangePath := fldPath.Child("tokenExchange")
+		if u, err := url.Parse(tokenExchange.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(tokenExchangePath.Child("url"), tokenExchange.URL, "must be a valid https URL"))
+		}
+		if len(tokenExchange.CertificateAuthorityData) > 0 {
+			if _, err := cert.ParseCertsPEM(tokenExchange.CertificateAuthorityData); err != nil {
+				allErrs = append(allErrs, field.Invalid(tokenExchangePath.Child("certificateAuthorityData"), "(omitted)", fmt.Sprintf("must be a bundle of PEM encoded certificates: %v", err)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// ValidateWorkloadIdentityConfigUpdate validates updates of a WorkloadIdentityConfig object.
+func ValidateWorkloadIdentityConfigUpdate(oldConfig, newConfig *apismetal.WorkloadIdentityConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(newConfig.Namespace, oldConfig.Namespace, fldPath.Child("namespace"))...)
+	allErrs = append(allErrs, ValidateWorkloadIdentityConfig(newConfig, fldPath)...)
+
+	return allErrs
+}
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/util/cert"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+var _ = Describe("WorkloadIdentityConfig validation", func() {
+	var (
+		config  *apismetal.WorkloadIdentityConfig
+		fldPath *field.Path
+	)
+
+	BeforeEach(func() {
+		config = &apismetal.WorkloadIdentityConfig{
+			Namespace: "default",
+		}
+		fldPath = field.NewPath("providerConfig")
+	})
+
+	Describe("#ValidateWorkloadIdentityConfig", func() {
+		It("should succeed for a valid config", func() {
+			Expect(ValidateWorkloadIdentityConfig(config, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid an empty namespace", func() {
+			config.Namespace = ""
+
+			Expect(ValidateWorkloadIdentityConfig(config, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeRequired),
+					"Field": Equal("providerConfig.namespace"),
+				})),
+			))
+		})
+
+		It("should forbid an invalid namespace", func() {
+			config.Namespace = "%foo"
+
+			Expect(ValidateWorkloadIdentityConfig(config, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.namespace"),
+				})),
+			))
+		})
+
+		It("should succeed for a valid token exchange", func() {
+			caCert, _, err := cert.GenerateSelfSignedCertKey("sts.example.com", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			config.TokenExchange = &apismetal.TokenExchangeConfig{
+				URL:                      "https://sts.example.com/token",
+				Audience:                 "metal",
+				CertificateAuthorityData: caCert,
+			}
+
+			Expect(ValidateWorkloadIdentityConfig(config, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid a non-https token exchange URL and an invalid CA bundle", func() {
+			config.TokenExchange = &apismetal.TokenExchangeConfig{
+				URL:                      "http://sts.example.com/token",
+				CertificateAuthorityData: []byte("foo"),
+			}
+
+			Expect(ValidateWorkloadIdentityConfig(config, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.tokenExchange.url"),
+				})),
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.tokenExchange.certificateAuthorityData"),
+				})),
+			))
+		})
+	})
+
+	Describe("#ValidateWorkloadIdentityConfigUpdate", func() {
+		It("should allow updating the token exchange", func() {
+			newConfig := config.DeepCopy()
+			newConfig.TokenExchange = &apismetal.TokenExchangeConfig{URL: "https://sts.example.com/token"}
+
+			Expect(ValidateWorkloadIdentityConfigUpdate(config, newConfig, fldPath)).To(BeEmpty())
+		})
+
+		It("should forbid changing the namespace", func() {
+			newConfig := config.DeepCopy()
+			newConfig.Namespace = "other"
+
+			Expect(ValidateWorkloadIdentityConfigUpdate(config, newConfig, fldPath)).To(ConsistOf(
+				PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(field.ErrorTypeInvalid),
+					"Field": Equal("providerConfig.namespace"),
+				})),
+			))
+		})
+	})
+})
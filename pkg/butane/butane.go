@@ -0,0 +1,416 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package butane
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/yaml"
+)
+
+// ignitionVersions maps the supported Butane variants and versions to the Ignition spec version they translate to.
+var ignitionVersions = map[string]map[string]string{
+	"fcos": {
+		"1.0.0": "3.0.0",
+		"1.1.0": "3.1.0",
+		"1.2.0": "3.2.0",
+		"1.3.0": "3.2.0",
+		"1.4.0": "3.3.0",
+		"1.5.0": "3.4.0",
+		"1.6.0": "3.5.0",
+	},
+	"flatcar": {
+		"1.0.0": "3.3.0",
+		"1.1.0": "3.4.0",
+	},
+}
+
+// irregularFields contains the Butane fields whose Ignition name is not the camel case form of the Butane name.
+var irregularFields = map[string]string{
+	"size_mib":  "sizeMiB",
+	"start_mib": "startMiB",
+}
+
+// node describes the fields of a Butane object, the entries of a Butane list or, if empty, a scalar value.
+type node struct {
+	fields map[string]field
+	items  *node
+}
+
+// field is a field of a Butane object which is available since the given Ignition spec version.
+type field struct {
+	since string
+	node  *node
+}
+
+var scalar = &node{}
+
+func object(fields map[string]field) *node {
+	return &node{fields: fields}
+}
+
+func list(items *node) *node {
+	return &node{items: items}
+}
+
+func always(n *node) field {
+	return field{since: "3.0.0", node: n}
+}
+
+func since(version string, n *node) field {
+	return field{since: version, node: n}
+}
+
+// resource returns the schema of an Ignition resource. inline is the only Butane sugar which is supported, it is
+// translated to a data URL in source.
+func resource(compressionSince string) *node {
+	return object(map[string]field{
+		"compression": since(compressionSince, scalar),
+		"http_headers": since("3.1.0", list(object(map[string]field{
+			"name":  always(scalar),
+			"value": always(scalar),
+		}))),
+		"inline":       always(scalar),
+		"source":       always(scalar),
+		"verification": always(object(map[string]field{"hash": always(scalar)})),
+	})
+}
+
+var nodeOwner = object(map[string]field{
+	"id":   always(scalar),
+	"name": always(scalar),
+})
+
+// schema contains the fields of a Butane config which have a counterpart in the Ignition spec. All other fields, e.g.
+// Butane sugar like boot_device, grub or with_mount_unit of filesystems, are rejected.
+var schema = object(map[string]field{
+	"ignition": always(object(map[string]field{
+		"config": always(object(map[string]field{
+			"merge":   always(list(resource("3.1.0"))),
+			"replace": always(resource("3.1.0")),
+		})),
+		"proxy": since("3.1.0", object(map[string]field{
+			"http_proxy":  always(scalar),
+			"https_proxy": always(scalar),
+			"no_proxy":    always(list(scalar)),
+		})),
+		"security": always(object(map[string]field{
+			"tls": always(object(map[string]field{
+				"certificate_authorities": always(list(resource("3.1.0"))),
+			})),
+		})),
+		"timeouts": always(object(map[string]field{
+			"http_response_headers": always(scalar),
+			"http_total":            always(scalar),
+		})),
+	})),
+	"kernel_arguments": since("3.3.0", object(map[string]field{
+		"should_exist":     always(list(scalar)),
+		"should_not_exist": always(list(scalar)),
+	})),
+	"passwd": always(object(map[string]field{
+		"groups": always(list(object(map[string]field{
+			"gid":           always(scalar),
+			"name":          always(scalar),
+			"password_hash": always(scalar),
+			"should_exist":  since("3.2.0", scalar),
+			"system":        always(scalar),
+		}))),
+		"users": always(list(object(map[string]field{
+			"gecos":               always(scalar),
+			"groups":              always(list(scalar)),
+			"home_dir":            always(scalar),
+			"name":                always(scalar),
+			"no_create_home":      always(scalar),
+			"no_log_init":         always(scalar),
+			"no_user_group":       always(scalar),
+			"password_hash":       always(scalar),
+			"primary_group":       always(scalar),
+			"shell":               always(scalar),
+			"should_exist":        since("3.2.0", scalar),
+			"ssh_authorized_keys": always(list(scalar)),
+			"system":              always(scalar),
+			"uid":                 always(scalar),
+		}))),
+	})),
+	"storage": always(object(map[string]field{
+		"directories": always(list(object(map[string]field{
+			"group":     always(nodeOwner),
+			"mode":      always(scalar),
+			"overwrite": always(scalar),
+			"path":      always(scalar),
+			"user":      always(nodeOwner),
+		}))),
+		"disks": always(list(object(map[string]field{
+			"device": always(scalar),
+			"partitions": always(list(object(map[string]field{
+				"guid":                 always(scalar),
+				"label":                always(scalar),
+				"number":               always(scalar),
+				"resize":               since("3.2.0", scalar),
+				"should_exist":         always(scalar),
+				"size_mib":             always(scalar),
+				"start_mib":            always(scalar),
+				"type_guid":            always(scalar),
+				"wipe_partition_entry": always(scalar),
+			}))),
+			"wipe_table": always(scalar),
+		}))),
+		"files": always(list(object(map[string]field{
+			"append":    always(list(resource("3.0.0"))),
+			"contents":  always(resource("3.0.0")),
+			"group":     always(nodeOwner),
+			"mode":      always(scalar),
+			"overwrite": always(scalar),
+			"path":      always(scalar),
+			"user":      always(nodeOwner),
+		}))),
+		"filesystems": always(list(object(map[string]field{
+			"device":          always(scalar),
+			"format":          always(scalar),
+			"label":           always(scalar),
+			"mount_options":   since("3.1.0", list(scalar)),
+			"options":         always(list(scalar)),
+			"path":            always(scalar),
+			"uuid":            always(scalar),
+			"wipe_filesystem": always(scalar),
+		}))),
+		"links": always(list(object(map[string]field{
+			"group":     always(nodeOwner),
+			"hard":      always(scalar),
+			"overwrite": always(scalar),
+			"path":      always(scalar),
+			"target":    always(scalar),
+			"user":      always(nodeOwner),
+		}))),
+		"luks": since("3.2.0", list(object(map[string]field{
+			"cex": since("3.5.0", object(map[string]field{
+				"enabled": always(scalar),
+			})),
+			"clevis": always(object(map[string]field{
+				"custom": always(object(map[string]field{
+					"config":        always(scalar),
+					"needs_network": always(scalar),
+					"pin":           always(scalar),
+				})),
+				"tang": always(list(object(map[string]field{
+					"advertisement": since("3.4.0", scalar),
+					"thumbprint":    always(scalar),
+					"url":           always(scalar),
+				}))),
+				"threshold": always(scalar),
+				"tpm2":      always(scalar),
+			})),
+			"device":       always(scalar),
+			"discard":      since("3.4.0", scalar),
+			"key_file":     always(resource("3.0.0")),
+			"label":        always(scalar),
+			"name":         always(scalar),
+			"open_options": since("3.4.0", list(scalar)),
+			"options":      always(list(scalar)),
+			"uuid":         always(scalar),
+			"wipe_volume":  always(scalar),
+		}))),
+		"raid": always(list(object(map[string]field{
+			"devices": always(list(scalar)),
+			"level":   always(scalar),
+			"name":    always(scalar),
+			"options": always(list(scalar)),
+			"spares":  always(scalar),
+		}))),
+	})),
+	"systemd": always(object(map[string]field{
+		"units": always(list(object(map[string]field{
+			"contents": always(scalar),
+			"dropins": always(list(object(map[string]field{
+				"contents": always(scalar),
+				"name":     always(scalar),
+			}))),
+			"enabled": always(scalar),
+			"mask":    always(scalar),
+			"name":    always(scalar),
+		}))),
+	})),
+})
+
+// ReportEntry is a problem found while translating a Butane config.
+type ReportEntry struct {
+	// Path is the path of the offending Butane field, empty for problems of the whole config.
+	Path string
+	// Message describes the problem.
+	Message string
+}
+
+func (e ReportEntry) String() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Report contains all problems found while translating a Butane config.
+type Report struct {
+	Entries []ReportEntry
+}
+
+func (r *Report) add(path, format string, args ...interface{}) {
+	r.Entries = append(r.Entries, ReportEntry{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// IsFatal returns whether the Butane config could not be translated.
+func (r Report) IsFatal() bool {
+	return len(r.Entries) > 0
+}
+
+func (r Report) String() string {
+	entries := make([]string, 0, len(r.Entries))
+	for _, entry := range r.Entries {
+		entries = append(entries, entry.String())
+	}
+	return strings.Join(entries, "; ")
+}
+
+// Translate translates the given Butane config into an Ignition config. Only the variants and versions in
+// ignitionVersions are supported. Fields which are not part of the Ignition spec the version translates to are
+// rejected, this includes local file references and all Butane sugar except inline contents. The returned report
+// lists all problems of the config; if it is fatal, an error summarizing it is returned as well.
+func Translate(data []byte) (map[string]interface{}, Report, error) {
+	var report Report
+
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		report.add("", "failed to parse butane config: %v", err)
+		return nil, report, reportError(report)
+	}
+
+	variant, _ := config["variant"].(string)
+	version, _ := config["version"].(string)
+	ignitionVersion := ""
+	if versions, ok := ignitionVersions[variant]; !ok {
+		report.add("variant", "unsupported butane variant %q", variant)
+	} else if ignitionVersion, ok = versions[version]; !ok {
+		report.add("version", "unsupported butane version %q for variant %s", version, variant)
+	}
+	if report.IsFatal() {
+		return nil, report, reportError(report)
+	}
+	delete(config, "variant")
+	delete(config, "version")
+
+	t := &translator{report: &report, variant: variant, version: version, ignitionVersion: semver.MustParse(ignitionVersion)}
+	ignition := t.translateObject("", config, schema)
+	if report.IsFatal() {
+		return nil, report, reportError(report)
+	}
+
+	ignitionSection, ok := ignition["ignition"].(map[string]interface{})
+	if !ok {
+		ignitionSection = map[string]interface{}{}
+		ignition["ignition"] = ignitionSection
+	}
+	ignitionSection["version"] = ignitionVersion
+
+	return ignition, report, nil
+}
+
+func reportError(report Report) error {
+	return fmt.Errorf("could not translate butane config: %s", report)
+}
+
+type translator struct {
+	report          *Report
+	variant         string
+	version         string
+	ignitionVersion *semver.Version
+}
+
+func (t *translator) translateValue(path string, value interface{}, n *node) interface{} {
+	switch {
+	case n.fields != nil:
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			t.report.add(path, "expected an object, got %T", value)
+			return nil
+		}
+		return t.translateObject(path, object, n)
+	case n.items != nil:
+		entries, ok := value.([]interface{})
+		if !ok {
+			t.report.add(path, "expected a list, got %T", value)
+			return nil
+		}
+		list := make([]interface{}, 0, len(entries))
+		for i, entry := range entries {
+			list = append(list, t.translateValue(fmt.Sprintf("%s[%d]", path, i), entry, n.items))
+		}
+		return list
+	default:
+		return value
+	}
+}
+
+func (t *translator) translateObject(path string, object map[string]interface{}, n *node) map[string]interface{} {
+	translated := make(map[string]interface{}, len(object))
+
+	// iterate in a stable order, so that the report lists the problems deterministically
+	for _, key := range slices.Sorted(maps.Keys(object)) {
+		value := object[key]
+		fieldPath := key
+		if path != "" {
+			fieldPath = path + "." + key
+		}
+
+		if key == "local" || strings.HasSuffix(key, "_local") {
+			t.report.add(fieldPath, "unsupported butane field, local files cannot be embedded")
+			continue
+		}
+		field, ok := n.fields[key]
+		if !ok {
+			t.report.add(fieldPath, "unsupported butane field")
+			continue
+		}
+		if t.ignitionVersion.LessThan(semver.MustParse(field.since)) {
+			t.report.add(fieldPath, "unsupported butane field for variant %s version %s", t.variant, t.version)
+			continue
+		}
+
+		if key == "inline" {
+			if _, ok := object["source"]; ok {
+				t.report.add(fieldPath, "inline and source are mutually exclusive")
+				continue
+			}
+			inline, ok := value.(string)
+			if !ok {
+				t.report.add(fieldPath, "expected a string, got %T", value)
+				continue
+			}
+			translated["source"] = "data:," + url.PathEscape(inline)
+			continue
+		}
+
+		translated[ignitionFieldName(key)] = t.translateValue(fieldPath, value, field.node)
+	}
+
+	return translated
+}
+
+// ignitionFieldName converts the snake case name of a Butane field to the camel case name of the Ignition field.
+func ignitionFieldName(name string) string {
+	if ignitionName, ok := irregularFields[name]; ok {
+		return ignitionName
+	}
+
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package butane_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/butane"
+)
+
+var _ = Describe("Butane", func() {
+	toMap := func(data string) map[string]interface{} {
+		m := map[string]interface{}{}
+		Expect(yaml.Unmarshal([]byte(data), &m)).To(Succeed())
+		return m
+	}
+
+	Describe("#Translate", func() {
+		It("should translate a butane config to ignition", func() {
+			ignition, _, err := butane.Translate([]byte(`
+variant: flatcar
+version: 1.0.0
+passwd:
+  users:
+  - name: core
+    ssh_authorized_keys:
+    - ssh-ed25519 AAAA
+storage:
+  disks:
+  - device: /dev/sda
+    wipe_table: true
+    partitions:
+    - label: data
+      size_mib: 1024
+  files:
+  - path: /etc/motd
+    mode: 0644
+    contents:
+      inline: Hello, world!
+systemd:
+  units:
+  - name: foo.service
+    enabled: true
+    contents: |
+      [Service]
+      ExecStart=/usr/bin/true
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ignition).To(Equal(toMap(`
+ignition:
+  version: 3.3.0
+passwd:
+  users:
+  - name: core
+    sshAuthorizedKeys:
+    - ssh-ed25519 AAAA
+storage:
+  disks:
+  - device: /dev/sda
+    wipeTable: true
+    partitions:
+    - label: data
+      sizeMiB: 1024
+  files:
+  - path: /etc/motd
+    mode: 420
+    contents:
+      source: data:,Hello%2C%20world%21
+systemd:
+  units:
+  - name: foo.service
+    enabled: true
+    contents: |
+      [Service]
+      ExecStart=/usr/bin/true
+`)))
+		})
+
+		It("should keep additional ignition settings", func() {
+			ignition, _, err := butane.Translate([]byte(`
+variant: fcos
+version: 1.5.0
+ignition:
+  timeouts:
+    http_total: 10
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ignition).To(Equal(toMap(`
+ignition:
+  version: 3.4.0
+  timeouts:
+    httpTotal: 10
+`)))
+		})
+
+		It("should fail for an unsupported variant or version", func() {
+			_, _, err := butane.Translate([]byte(`{"variant": "foo", "version": "1.0.0"}`))
+			Expect(err).To(MatchError(ContainSubstring(`unsupported butane variant "foo"`)))
+
+			_, _, err = butane.Translate([]byte(`{"variant": "flatcar", "version": "0.1.0"}`))
+			Expect(err).To(MatchError(ContainSubstring(`unsupported butane version "0.1.0"`)))
+		})
+
+		It("should fail for local file references", func() {
+			_, _, err := butane.Translate([]byte(`
+variant: flatcar
+version: 1.0.0
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      local: foo
+`))
+			Expect(err).To(MatchError(ContainSubstring("storage.files[0].contents.local")))
+		})
+
+		It("should fail for butane sugar which is not part of the ignition spec", func() {
+			_, report, err := butane.Translate([]byte(`
+variant: fcos
+version: 1.5.0
+grub:
+  users:
+  - name: root
+storage:
+  filesystems:
+  - device: /dev/disk/by-label/data
+    path: /var/data
+    format: xfs
+    with_mount_unit: true
+`))
+			Expect(err).To(HaveOccurred())
+			Expect(report.Entries).To(Equal([]butane.ReportEntry{
+				{Path: "grub", Message: "unsupported butane field"},
+				{Path: "storage.filesystems[0].with_mount_unit", Message: "unsupported butane field"},
+			}))
+		})
+
+		It("should fail for fields which are not part of the ignition spec of the version", func() {
+			_, report, err := butane.Translate([]byte(`
+variant: fcos
+version: 1.0.0
+kernel_arguments:
+  should_exist: [quiet]
+ignition:
+  version: 3.0.0
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      inline: foo
+      http_headers:
+      - name: foo
+        value: bar
+`))
+			Expect(err).To(HaveOccurred())
+			Expect(report.Entries).To(Equal([]butane.ReportEntry{
+				{Path: "ignition.version", Message: "unsupported butane field"},
+				{Path: "kernel_arguments", Message: "unsupported butane field for variant fcos version 1.0.0"},
+				{Path: "storage.files[0].contents.http_headers", Message: "unsupported butane field for variant fcos version 1.0.0"},
+			}))
+		})
+
+		It("should fail for values of the wrong kind", func() {
+			_, report, err := butane.Translate([]byte(`
+variant: flatcar
+version: 1.0.0
+storage:
+  files: /etc/foo
+systemd:
+  units:
+  - foo.service
+`))
+			Expect(err).To(HaveOccurred())
+			Expect(report.Entries).To(Equal([]butane.ReportEntry{
+				{Path: "storage.files", Message: "expected a list, got string"},
+				{Path: "systemd.units[0]", Message: "expected an object, got string"},
+			}))
+		})
+
+		It("should fail for inline contents with a source", func() {
+			_, _, err := butane.Translate([]byte(`
+variant: flatcar
+version: 1.0.0
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      inline: foo
+      source: https://example.org/foo
+`))
+			Expect(err).To(MatchError(ContainSubstring("inline and source are mutually exclusive")))
+		})
+
+		It("should report all problems of the config with their paths", func() {
+			ignition, report, err := butane.Translate([]byte(`
+variant: flatcar
+version: 1.0.0
+boot_device:
+  mirror:
+    devices: [/dev/sda, /dev/sdb]
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      local: foo
+  - path: /etc/bar
+    contents:
+      inline: bar
+      source: https://example.org/bar
+`))
+			Expect(err).To(HaveOccurred())
+			Expect(ignition).To(BeNil())
+			Expect(report.IsFatal()).To(BeTrue())
+			Expect(report.Entries).To(Equal([]butane.ReportEntry{
+				{Path: "boot_device", Message: "unsupported butane field"},
+				{Path: "storage.files[0].contents.local", Message: "unsupported butane field, local files cannot be embedded"},
+				{Path: "storage.files[1].contents.inline", Message: "inline and source are mutually exclusive"},
+			}))
+			Expect(err).To(MatchError(ContainSubstring(report.String())))
+		})
+
+		It("should fail for invalid yaml", func() {
+			_, _, err := butane.Translate([]byte(`variant: [`))
+			Expect(err).To(MatchError(ContainSubstring("failed to parse butane config")))
+		})
+	})
+})
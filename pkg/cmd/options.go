@@ -17,6 +17,7 @@ import (
 	controlplanecontroller "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/controller/controlplane"
 	healthcheckcontroller "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/controller/healthcheck"
 	infrastructurecontroller "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/controller/infrastructure"
+	tokenexchangecontroller "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/controller/tokenexchange"
 	workercontroller "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/controller/worker"
 	cloudproviderwebhook "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/webhook/cloudprovider"
 	controlplanewebhook "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/webhook/controlplane"
@@ -30,6 +31,7 @@ func ControllerSwitchOptions() *controllercmd.SwitchOptions {
 		controllercmd.Switch(extensionsworkercontroller.ControllerName, workercontroller.AddToManager),
 		controllercmd.Switch(extensionshealthcheckcontroller.ControllerName, healthcheckcontroller.AddToManager),
 		controllercmd.Switch(extensionsheartbeatcontroller.ControllerName, extensionsheartbeatcontroller.AddToManager),
+		controllercmd.Switch(tokenexchangecontroller.ControllerName, tokenexchangecontroller.AddToManager),
 	)
 }
 
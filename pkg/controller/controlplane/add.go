@@ -45,7 +45,7 @@ func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddO
 		controlPlaneChart,
 		controlPlaneShootChart,
 		nil,
-		nil,
+		storageClassChart,
 		nil,
 		NewValuesProvider(mgr),
 		extensionscontroller.ChartRendererFactoryFunc(util.NewChartRendererForShoot),
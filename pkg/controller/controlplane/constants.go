@@ -12,4 +12,12 @@ const (
 	StorageClassDefaultKeyName = "default"
 	// StorageClassExpandableKeyName is the expandable key name of the StorageClass value map
 	StorageClassExpandableKeyName = "expandable"
+	// StorageClassProvisionerKeyName is the provisioner key name of the StorageClass value map
+	StorageClassProvisionerKeyName = "provisioner"
+	// StorageClassParametersKeyName is the parameters key name of the StorageClass value map
+	StorageClassParametersKeyName = "parameters"
+	// StorageClassReclaimPolicyKeyName is the reclaim policy key name of the StorageClass value map
+	StorageClassReclaimPolicyKeyName = "reclaimPolicy"
+	// StorageClassVolumeBindingModeKeyName is the volume binding mode key name of the StorageClass value map
+	StorageClassVolumeBindingModeKeyName = "volumeBindingMode"
 )
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -22,8 +23,11 @@ import (
 	kutil "github.com/gardener/gardener/pkg/utils/kubernetes"
 	secretutils "github.com/gardener/gardener/pkg/utils/secrets"
 	secretsmanager "github.com/gardener/gardener/pkg/utils/secrets/manager"
+	monitoringv1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1"
+	monitoringv1alpha1 "github.com/prometheus-operator/prometheus-operator/pkg/apis/monitoring/v1alpha1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -34,8 +38,10 @@ import (
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/charts"
 	metalapi "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/internal"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	metalhelper "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
 const (
@@ -89,6 +95,13 @@ var (
 		Name:       "seed-controlplane",
 		EmbeddedFS: charts.InternalChart,
 		Path:       filepath.Join(charts.InternalChartsPath, "seed-controlplane"),
+		Objects: []*chart.Object{
+			{Type: &networkingv1.NetworkPolicy{}, Name: metal.MetalAPIEgressNetworkPolicyName},
+			{Type: &monitoringv1alpha1.ScrapeConfig{}, Name: "shoot-metallb-controller"},
+			{Type: &monitoringv1alpha1.ScrapeConfig{}, Name: "shoot-metallb-speaker"},
+			{Type: &monitoringv1.PrometheusRule{}, Name: "shoot-metallb"},
+			{Type: &corev1.ConfigMap{}, Name: "metallb-dashboards"},
+		},
 		SubCharts: []*chart.Chart{
 			{
 				Name:   metal.CloudControllerManagerName,
@@ -96,7 +109,9 @@ var (
 				Objects: []*chart.Object{
 					{Type: &corev1.Service{}, Name: "cloud-controller-manager"},
 					{Type: &appsv1.Deployment{}, Name: "cloud-controller-manager"},
-					{Type: &corev1.ConfigMap{}, Name: "cloud-controller-manager-observability-config"},
+					{Type: &monitoringv1.ServiceMonitor{}, Name: "shoot-cloud-controller-manager"},
+					{Type: &monitoringv1.PrometheusRule{}, Name: "shoot-cloud-controller-manager"},
+					{Type: &corev1.ConfigMap{}, Name: "cloud-controller-manager-dashboards"},
 					{Type: &autoscalingv1.VerticalPodAutoscaler{}, Name: "cloud-controller-manager-vpa"},
 				},
 			},
@@ -106,6 +121,11 @@ var (
 				Images: []string{metal.MetalLoadBalancerControllerManagerImageName},
 				Objects: []*chart.Object{
 					{Type: &appsv1.Deployment{}, Name: "metal-load-balancer-controller-manager"},
+					{Type: &corev1.Service{}, Name: "metal-load-balancer-controller-manager"},
+					{Type: &monitoringv1.ServiceMonitor{}, Name: "shoot-metal-load-balancer-controller-manager"},
+					{Type: &monitoringv1alpha1.ScrapeConfig{}, Name: "shoot-metal-load-balancer-controller-speaker"},
+					{Type: &monitoringv1.PrometheusRule{}, Name: "shoot-metal-load-balancer-controller"},
+					{Type: &corev1.ConfigMap{}, Name: "metal-load-balancer-controller-dashboards"},
 				},
 			},
 		},
@@ -162,8 +182,22 @@ var (
 					{Type: &rbacv1.RoleBinding{}, Name: "metal-load-balancer-controller-manager-leader-election"},
 				},
 			},
+			{
+				Name: "cluster-autoscaler-priority-expander",
+				Path: filepath.Join(charts.InternalChartsPath, "cluster-autoscaler-priority-expander"),
+				Objects: []*chart.Object{
+					{Type: &corev1.ConfigMap{}, Name: metal.ClusterAutoscalerPriorityExpanderName},
+				},
+			},
 		},
 	}
+
+	storageClassChart = &chart.Chart{
+		Name:       "shoot-storageclasses",
+		EmbeddedFS: charts.InternalChart,
+		Path:       filepath.Join(charts.InternalChartsPath, "shoot-storageclasses"),
+		Images:     []string{metal.LocalVolumeProvisionerImageName},
+	}
 )
 
 // valuesProvider is a ValuesProvider that provides metal-specific values for the 2 charts applied by the generic actuator.
@@ -261,8 +295,72 @@ func (vp *valuesProvider) GetStorageClassesChartValues(
 	controlPlane *extensionsv1alpha1.ControlPlane,
 	cluster *extensionscontroller.Cluster,
 ) (map[string]any, error) {
-	values := make(map[string]any)
-	return values, nil
+	cpConfig := &metalapi.ControlPlaneConfig{}
+	if controlPlane.Spec.ProviderConfig != nil {
+		if _, _, err := vp.decoder.Decode(controlPlane.Spec.ProviderConfig.Raw, nil, cpConfig); err != nil {
+			return nil, fmt.Errorf("could not decode providerConfig of controlplane '%s': %w", client.ObjectKeyFromObject(controlPlane), err)
+		}
+	}
+
+	if cpConfig.Storage == nil {
+		return map[string]any{
+			"storageClasses": []map[string]any{},
+			"localVolumeProvisioner": map[string]any{
+				"enabled": false,
+			},
+		}, nil
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return getStorageClassesChartValues(cpConfig.Storage, cloudProfileConfig.StorageClasses)
+}
+
+// getStorageClassesChartValues collects and returns the storage classes chart values.
+func getStorageClassesChartValues(storage *metalapi.StorageConfig, available metalapi.StorageClasses) (map[string]any, error) {
+	storageClasses, defaultStorageClass, err := metalhelper.SelectStorageClasses(storage, available)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		storageClassValues = make([]map[string]any, 0, len(storageClasses))
+		localStorageValues = make([]map[string]any, 0, len(storageClasses))
+	)
+	for _, sc := range storageClasses {
+		values := map[string]any{
+			StorageClassNameKeyName:        sc.Name,
+			StorageClassTypeKeyName:        sc.Type,
+			StorageClassDefaultKeyName:     sc.Name == defaultStorageClass,
+			StorageClassExpandableKeyName:  false,
+			StorageClassProvisionerKeyName: metal.LocalStorageProvisioner,
+			StorageClassParametersKeyName:  sc.Parameters,
+		}
+		if sc.ReclaimPolicy != nil {
+			values[StorageClassReclaimPolicyKeyName] = string(*sc.ReclaimPolicy)
+		}
+		if sc.VolumeBindingMode != nil {
+			values[StorageClassVolumeBindingModeKeyName] = string(*sc.VolumeBindingMode)
+		}
+		storageClassValues = append(storageClassValues, values)
+
+		localStorageValues = append(localStorageValues, map[string]any{
+			"name":    sc.Name,
+			"hostDir": path.Join(metal.LocalVolumeHostDirPrefix, sc.Type),
+		})
+	}
+
+	return map[string]any{
+		"storageClasses": storageClassValues,
+		"localVolumeProvisioner": map[string]any{
+			"enabled":         len(localStorageValues) > 0,
+			"volumeTypeLabel": metal.VolumeTypeLabel,
+			"storageClasses":  localStorageValues,
+		},
+	}, nil
 }
 
 // getControlPlaneChartValues collects and returns the control plane chart values.
@@ -277,12 +375,17 @@ func getControlPlaneChartValues(
 	map[string]any,
 	error,
 ) {
-	ccm, err := getCCMChartValues(cpConfig, cp, cluster, secretsReader, checksums, scaledDown)
+	metalAPIEgress, err := getMetalAPIEgress(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	ccm, err := getCCMChartValues(cpConfig, cp, cluster, secretsReader, checksums, scaledDown, metalAPIEgress != nil)
 	if err != nil {
 		return nil, err
 	}
 
-	metalLoadBalancerControllerManager, err := getMetalLoadBalancerControllerManagerChartValues(cpConfig)
+	metalLoadBalancerControllerManager, err := getMetalLoadBalancerControllerManagerChartValues(cpConfig, metalAPIEgress != nil)
 	if err != nil {
 		return nil, err
 	}
@@ -291,22 +394,77 @@ func getControlPlaneChartValues(
 		"global": map[string]any{
 			"genericTokenKubeconfigSecretName": extensionscontroller.GenericTokenKubeconfigSecretNameFromCluster(cluster),
 		},
+		"metalAPIEgress":                             getMetalAPIEgressChartValues(metalAPIEgress),
+		metal.MetallbName:                            getMetallbMonitoringChartValues(cpConfig),
 		metal.CloudControllerManagerName:             ccm,
 		metal.MetalLoadBalancerControllerManagerName: metalLoadBalancerControllerManager,
 	}, nil
 }
 
-func getMetalLoadBalancerControllerManagerChartValues(config *metalapi.ControlPlaneConfig) (map[string]any, error) {
+// getMetalAPIEgress returns the egress traffic to the metal API of the shoot's region, if it can be determined.
+func getMetalAPIEgress(cluster *extensionscontroller.Cluster) (*metalhelper.MetalAPIEgress, error) {
+	if cluster.Shoot == nil {
+		return nil, nil
+	}
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return metalhelper.FindMetalAPIEgress(cloudProfileConfig, cluster.Shoot.Spec.Region)
+}
+
+func getMetalAPIEgressChartValues(egress *metalhelper.MetalAPIEgress) map[string]any {
+	if egress == nil {
+		return map[string]any{
+			"enabled": false,
+		}
+	}
+
+	return map[string]any{
+		"enabled": true,
+		"name":    metal.MetalAPIEgressNetworkPolicyName,
+		"podSelectorLabels": map[string]any{
+			metal.AllowEgressToMetalAPILabel: "allowed",
+		},
+		"cidrs": egress.CIDRs,
+		"ports": egress.Ports,
+	}
+}
+
+// getMetallbMonitoringChartValues returns the chart values of the monitoring configuration of the MetalLB deployed to
+// the shoot.
+func getMetallbMonitoringChartValues(cpConfig *metalapi.ControlPlaneConfig) map[string]any {
+	if cpConfig.LoadBalancerConfig == nil || cpConfig.LoadBalancerConfig.MetallbConfig == nil {
+		return map[string]any{
+			"enabled": false,
+		}
+	}
+
+	return map[string]any{
+		"enabled": true,
+		"speaker": map[string]any{
+			"enabled": cpConfig.LoadBalancerConfig.MetallbConfig.EnableSpeaker,
+		},
+	}
+}
+
+func getMetalLoadBalancerControllerManagerChartValues(config *metalapi.ControlPlaneConfig, allowEgressToMetalAPI bool) (map[string]any, error) {
 	if config.LoadBalancerConfig == nil || config.LoadBalancerConfig.MetalLoadBalancerConfig == nil {
 		return map[string]any{
 			"enabled": false,
 		}, nil
 	}
 
+	podLabels := map[string]any{}
+	if allowEgressToMetalAPI {
+		podLabels[metal.AllowEgressToMetalAPILabel] = "allowed"
+	}
+
 	return map[string]any{
 		"enabled":           true,
 		"nodeCIDRMask":      config.LoadBalancerConfig.MetalLoadBalancerConfig.NodeCIDRMask,
 		"allocateNodeCIDRs": config.LoadBalancerConfig.MetalLoadBalancerConfig.AllocateNodeCIDRs,
+		"podLabels":         podLabels,
 	}, nil
 }
 
@@ -318,6 +476,7 @@ func getCCMChartValues(
 	secretsReader secretsmanager.Reader,
 	checksums map[string]string,
 	scaledDown bool,
+	allowEgressToMetalAPI bool,
 ) (map[string]any, error) {
 	serverSecret, found := secretsReader.Get(cloudControllerManagerServerName)
 	if !found {
@@ -331,6 +490,9 @@ func getCCMChartValues(
 	if ok && localAPI == "true" {
 		podLabels[metal.AllowEgressToIstioIngressLabel] = "allowed"
 	}
+	if allowEgressToMetalAPI {
+		podLabels[metal.AllowEgressToMetalAPILabel] = "allowed"
+	}
 
 	values := map[string]any{
 		"enabled":     true,
@@ -408,11 +570,38 @@ func (vp *valuesProvider) getControlPlaneShootChartValues(cluster *extensionscon
 		return nil, fmt.Errorf("failed to get metal load balancer controller chart values: %w", err)
 	}
 
+	clusterAutoscalerPriorityExpander, err := getClusterAutoscalerPriorityExpanderChartValues(cluster)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster-autoscaler priority expander chart values: %w", err)
+	}
+
 	return map[string]any{
 		metal.CloudControllerManagerName:             map[string]any{"enabled": true},
 		metal.MetallbName:                            metallb,
 		metal.CalicoBgpName:                          calicoBgp,
 		metal.MetalLoadBalancerControllerSpeakerName: metalLoadBalancerControllerSpeaker,
+		metal.ClusterAutoscalerPriorityExpanderName:  clusterAutoscalerPriorityExpander,
+	}, nil
+}
+
+// getClusterAutoscalerPriorityExpanderChartValues collects and returns the chart values of the priority expander
+// configuration of the cluster-autoscaler, which prefers worker pools by the priorities of their machine types.
+func getClusterAutoscalerPriorityExpanderChartValues(cluster *extensionscontroller.Cluster) (map[string]any, error) {
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	priorities := metalhelper.WorkerPoolPriorities(cloudProfileConfig, cluster.Shoot.Spec.Provider.Workers)
+	if priorities == nil {
+		return map[string]any{
+			"enabled": false,
+		}, nil
+	}
+
+	return map[string]any{
+		"enabled":    true,
+		"priorities": metalhelper.PriorityExpanderConfig(cluster.ObjectMeta.Name, priorities),
 	}, nil
 }
 
@@ -580,6 +769,7 @@ func getCalicoBgpChartValues(
 		if cpConfig.LoadBalancerConfig.CalicoBgpConfig.BgpPeer != nil {
 			for _, peer := range cpConfig.LoadBalancerConfig.CalicoBgpConfig.BgpPeer {
 				peerMap := map[string]any{
+					"name":         bgpPeerName(peer),
 					"peerIP":       peer.PeerIP,
 					"asNumber":     peer.ASNumber,
 					"nodeSelector": peer.NodeSelector,
@@ -612,6 +802,13 @@ func getCalicoBgpChartValues(
 	}, nil
 }
 
+// bgpPeerName returns the name of the BGPPeer resource of the given peer. The colons and brackets of IPv6 peer
+// addresses are not allowed in resource names and are replaced.
+func bgpPeerName(peer metalapi.BgpPeer) string {
+	peerIP := strings.NewReplacer("[", "", "]", "", ":", "-").Replace(peer.PeerIP)
+	return strings.Trim(fmt.Sprintf("bgppeer-%d-%s", peer.ASNumber, peerIP), "-")
+}
+
 func processFilters(filtersConfig []metalapi.BGPFilterRule) ([]map[string]any, error) {
 	var filters []map[string]any
 	for _, filter := range filtersConfig {
@@ -648,6 +845,9 @@ func parseAddressPool(cidr string) error {
 	if end == nil {
 		return fmt.Errorf("invalid IP range %q: invalid end IP %q", cidr, fs[1])
 	}
+	if (start.To4() == nil) != (end.To4() == nil) {
+		return fmt.Errorf("invalid IP range %q: start IP %q and end IP %q are of different IP families", cidr, start, end)
+	}
 	if bytes.Compare(start, end) > 0 {
 		return fmt.Errorf("invalid IP range %q: start IP %q is after the end IP %q", cidr, start, end)
 	}
@@ -27,6 +27,7 @@ import (
 	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/internal"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
@@ -215,6 +216,122 @@ var _ = Describe("Valueprovider Reconcile", func() {
 		})
 	})
 
+	Describe("#GetStorageClassesChartValues", func() {
+		It("should return no storage classes if no storage is configured", func(ctx SpecContext) {
+			cp := &extensionsv1alpha1.ControlPlane{
+				Spec: extensionsv1alpha1.ControlPlaneSpec{
+					DefaultSpec: extensionsv1alpha1.DefaultSpec{
+						Type: metal.Type,
+						ProviderConfig: &runtime.RawExtension{
+							Raw: encode(&apismetal.ControlPlaneConfig{}),
+						},
+					},
+				},
+			}
+
+			values, err := vp.GetStorageClassesChartValues(ctx, cp, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]any{
+				"storageClasses": []map[string]any{},
+				"localVolumeProvisioner": map[string]any{
+					"enabled": false,
+				},
+			}))
+		})
+
+		It("should return the selected storage classes of the cloud profile", func(ctx SpecContext) {
+			cp := &extensionsv1alpha1.ControlPlane{
+				Spec: extensionsv1alpha1.ControlPlaneSpec{
+					DefaultSpec: extensionsv1alpha1.DefaultSpec{
+						Type: metal.Type,
+						ProviderConfig: &runtime.RawExtension{
+							Raw: encode(&apismetal.ControlPlaneConfig{
+								Storage: &apismetal.StorageConfig{
+									StorageClasses:      []string{"local-ssd", "local-nvme"},
+									DefaultStorageClass: ptr.To("local-nvme"),
+								},
+							}),
+						},
+					},
+				},
+			}
+
+			providerCloudProfile := &metalv1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				StorageClasses: metalv1alpha1.StorageClasses{
+					Default: &metalv1alpha1.StorageClass{
+						Name: "local-hdd",
+						Type: "hdd",
+					},
+					Additional: []metalv1alpha1.StorageClass{
+						{
+							Name:          "local-ssd",
+							Type:          "ssd",
+							ReclaimPolicy: ptr.To(corev1.PersistentVolumeReclaimRetain),
+						},
+						{
+							Name:       "local-nvme",
+							Type:       "nvme",
+							Parameters: map[string]string{"foo": "bar"},
+						},
+					},
+				},
+			}
+			providerCloudProfileJson, err := json.Marshal(providerCloudProfile)
+			Expect(err).NotTo(HaveOccurred())
+			cluster := &controller.Cluster{
+				CloudProfile: &gardencorev1beta1.CloudProfile{
+					Spec: gardencorev1beta1.CloudProfileSpec{
+						ProviderConfig: &runtime.RawExtension{
+							Raw: providerCloudProfileJson,
+						},
+					},
+				},
+			}
+
+			values, err := vp.GetStorageClassesChartValues(ctx, cp, cluster)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(Equal(map[string]any{
+				"storageClasses": []map[string]any{
+					{
+						"name":          "local-ssd",
+						"type":          "ssd",
+						"default":       false,
+						"expandable":    false,
+						"provisioner":   "kubernetes.io/no-provisioner",
+						"parameters":    map[string]string(nil),
+						"reclaimPolicy": "Retain",
+					},
+					{
+						"name":        "local-nvme",
+						"type":        "nvme",
+						"default":     true,
+						"expandable":  false,
+						"provisioner": "kubernetes.io/no-provisioner",
+						"parameters":  map[string]string{"foo": "bar"},
+					},
+				},
+				"localVolumeProvisioner": map[string]any{
+					"enabled":         true,
+					"volumeTypeLabel": "storage.metal.ironcore.dev/volume-type",
+					"storageClasses": []map[string]any{
+						{
+							"name":    "local-ssd",
+							"hostDir": "/mnt/disks/ssd",
+						},
+						{
+							"name":    "local-nvme",
+							"hostDir": "/mnt/disks/nvme",
+						},
+					},
+				},
+			}))
+		})
+	})
+
 	Describe("#GetControlPlaneChartValues", func() {
 		It("should return correct config chart values", func(ctx SpecContext) {
 			cp := &extensionsv1alpha1.ControlPlane{
@@ -250,7 +367,20 @@ var _ = Describe("Valueprovider Reconcile", func() {
 					},
 				},
 			}
-			providerCloudProfile := &apismetal.CloudProfileConfig{}
+			providerCloudProfile := &metalv1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				RegionConfigs: []metalv1alpha1.RegionConfig{
+					{
+						Name:            "foo",
+						Server:          "https://10.0.0.1:6443",
+						FailoverServers: []string{"https://10.0.0.2"},
+						EgressCIDRs:     []string{"10.1.0.0/24"},
+					},
+				},
+			}
 			providerCloudProfileJson, err := json.Marshal(providerCloudProfile)
 			Expect(err).NotTo(HaveOccurred())
 			networkProviderConfig := &unstructured.Unstructured{Object: map[string]any{
@@ -276,6 +406,7 @@ var _ = Describe("Valueprovider Reconcile", func() {
 						Name:      "my-shoot",
 					},
 					Spec: gardencorev1beta1.ShootSpec{
+						Region: "foo",
 						Networking: &gardencorev1beta1.Networking{
 							ProviderConfig: &runtime.RawExtension{Raw: networkProviderConfigData},
 							Pods:           ptr.To[string]("10.0.0.0/16"),
@@ -307,10 +438,25 @@ var _ = Describe("Valueprovider Reconcile", func() {
 				"global": map[string]any{
 					"genericTokenKubeconfigSecretName": "generic-token-kubeconfig",
 				},
+				"metalAPIEgress": map[string]any{
+					"enabled": true,
+					"name":    "egress-to-metal-api",
+					"podSelectorLabels": map[string]any{
+						metal.AllowEgressToMetalAPILabel: "allowed",
+					},
+					"cidrs": []string{"10.0.0.1/32", "10.0.0.2/32", "10.1.0.0/24"},
+					"ports": []int32{443, 6443},
+				},
+				"metallb": map[string]any{
+					"enabled": false,
+				},
 				"metal-load-balancer-controller-manager": map[string]any{
 					"enabled":           true,
 					"nodeCIDRMask":      int32(80),
 					"allocateNodeCIDRs": true,
+					"podLabels": map[string]any{
+						metal.AllowEgressToMetalAPILabel: "allowed",
+					},
 				},
 				"cloud-controller-manager": map[string]any{
 					"enabled":     true,
@@ -323,6 +469,7 @@ var _ = Describe("Valueprovider Reconcile", func() {
 					"podLabels": map[string]any{
 						"maintenance.gardener.cloud/restart": "true",
 						metal.AllowEgressToIstioIngressLabel: "allowed",
+						metal.AllowEgressToMetalAPILabel:     "allowed",
 					},
 					"tlsCipherSuites": []string{
 						"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
@@ -421,6 +568,9 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]any{
 				"cloud-controller-manager": map[string]any{"enabled": true},
+				"cluster-autoscaler-priority-expander": map[string]any{
+					"enabled": false,
+				},
 				"metallb": map[string]any{
 					"enabled": false,
 				},
@@ -515,6 +665,9 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]any{
 				"cloud-controller-manager": map[string]any{"enabled": true},
+				"cluster-autoscaler-priority-expander": map[string]any{
+					"enabled": false,
+				},
 				"metallb": map[string]any{
 					"enabled": true,
 					"speaker": map[string]any{
@@ -594,6 +747,9 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]any{
 				"cloud-controller-manager": map[string]any{"enabled": true},
+				"cluster-autoscaler-priority-expander": map[string]any{
+					"enabled": false,
+				},
 				"metallb": map[string]any{
 					"enabled": false,
 				},
@@ -707,6 +863,9 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]any{
 				"cloud-controller-manager": map[string]any{"enabled": true},
+				"cluster-autoscaler-priority-expander": map[string]any{
+					"enabled": false,
+				},
 				"metallb": map[string]any{
 					"enabled": false,
 				},
@@ -721,11 +880,13 @@ var _ = Describe("Valueprovider Reconcile", func() {
 						"serviceClusterIPs":      []string{"10.10.10.0/24", "10.20.20.10-10.20.20.30"},
 						"bgpPeer": []map[string]any{
 							{
+								"name":         "bgppeer-12345-1.2.3.4",
 								"peerIP":       "1.2.3.4",
 								"asNumber":     12345,
 								"nodeSelector": "foo=bar",
 							},
 							{
+								"name":         "bgppeer-12345-1.2.3.5",
 								"peerIP":       "1.2.3.5",
 								"asNumber":     12345,
 								"nodeSelector": "foo=bar",
@@ -876,6 +1037,9 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(values).To(Equal(map[string]any{
 				"cloud-controller-manager": map[string]any{"enabled": true},
+				"cluster-autoscaler-priority-expander": map[string]any{
+					"enabled": false,
+				},
 				"metallb": map[string]any{
 					"enabled": false,
 				},
@@ -893,6 +1057,7 @@ var _ = Describe("Valueprovider Reconcile", func() {
 						"serviceClusterIPs":      []string{"10.10.10.0/24", "10.20.20.10-10.20.20.30"},
 						"bgpPeer": []map[string]any{
 							{
+								"name":         "bgppeer-12345-1.2.3.4",
 								"peerIP":       "1.2.3.4",
 								"asNumber":     12345,
 								"nodeSelector": "foo=bar",
@@ -901,6 +1066,7 @@ var _ = Describe("Valueprovider Reconcile", func() {
 								},
 							},
 							{
+								"name":         "bgppeer-12345-1.2.3.5",
 								"peerIP":       "1.2.3.5",
 								"asNumber":     12345,
 								"nodeSelector": "foo=bar",
@@ -950,6 +1116,107 @@ var _ = Describe("Valueprovider Reconcile", func() {
 			}))
 		})
 	})
+
+	Describe("#bgpPeerName", func() {
+		It("should return valid names for IPv4 and IPv6 peers", func() {
+			Expect(bgpPeerName(apismetal.BgpPeer{PeerIP: "1.2.3.4", ASNumber: 12345})).To(Equal("bgppeer-12345-1.2.3.4"))
+			Expect(bgpPeerName(apismetal.BgpPeer{PeerIP: "[2001:db8::1]:179", ASNumber: 12345})).To(Equal("bgppeer-12345-2001-db8--1-179"))
+		})
+	})
+
+	Describe("#parseAddressPool", func() {
+		It("should accept IPv4 and IPv6 ranges and reject ranges of mixed IP families", func() {
+			Expect(parseAddressPool("2001:db8::/64")).To(Succeed())
+			Expect(parseAddressPool("2001:db8::1-2001:db8::10")).To(Succeed())
+			Expect(parseAddressPool("10.0.0.1-2001:db8::10")).To(MatchError(ContainSubstring("different IP families")))
+		})
+	})
+
+	Describe("#getMetallbMonitoringChartValues", func() {
+		It("should only enable the monitoring of the deployed MetalLB components", func() {
+			Expect(getMetallbMonitoringChartValues(&apismetal.ControlPlaneConfig{})).To(Equal(map[string]any{
+				"enabled": false,
+			}))
+			Expect(getMetallbMonitoringChartValues(&apismetal.ControlPlaneConfig{
+				LoadBalancerConfig: &apismetal.LoadBalancerConfig{
+					MetallbConfig: &apismetal.MetallbConfig{EnableSpeaker: true},
+				},
+			})).To(Equal(map[string]any{
+				"enabled": true,
+				"speaker": map[string]any{
+					"enabled": true,
+				},
+			}))
+		})
+	})
+
+	Describe("#getClusterAutoscalerPriorityExpanderChartValues", func() {
+		var cluster *controller.Cluster
+
+		BeforeEach(func() {
+			cloudProfileConfig, err := json.Marshal(&metalv1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: metalv1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				MachineTypes: []metalv1alpha1.MachineType{
+					{Name: "cheap", Priority: ptr.To[int32](20)},
+					{Name: "abundant", Priority: ptr.To[int32](10)},
+					{Name: "expensive"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cluster = &controller.Cluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--bar"},
+				CloudProfile: &gardencorev1beta1.CloudProfile{
+					Spec: gardencorev1beta1.CloudProfileSpec{
+						ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+					},
+				},
+				Shoot: &gardencorev1beta1.Shoot{
+					Spec: gardencorev1beta1.ShootSpec{
+						Provider: gardencorev1beta1.Provider{
+							Workers: []gardencorev1beta1.Worker{
+								{Name: "a", Machine: gardencorev1beta1.Machine{Type: "expensive"}},
+								{Name: "b", Machine: gardencorev1beta1.Machine{Type: "cheap"}},
+								{Name: "c", Machine: gardencorev1beta1.Machine{Type: "abundant"}},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("should configure the priorities of the worker pools by their machine types", func() {
+			Expect(getClusterAutoscalerPriorityExpanderChartValues(cluster)).To(Equal(map[string]any{
+				"enabled": true,
+				"priorities": `20:
+- 'shoot--foo--bar-b-z[0-9]+$'
+10:
+- 'shoot--foo--bar-c-z[0-9]+$'
+0:
+- 'shoot--foo--bar-a-z[0-9]+$'
+`,
+			}))
+		})
+
+		It("should not configure the priority expander if the worker pools of the shoot have priorities", func() {
+			cluster.Shoot.Spec.Provider.Workers[0].Priority = ptr.To[int32](1)
+
+			Expect(getClusterAutoscalerPriorityExpanderChartValues(cluster)).To(Equal(map[string]any{
+				"enabled": false,
+			}))
+		})
+
+		It("should not configure the priority expander if no machine type has a priority", func() {
+			cluster.Shoot.Spec.Provider.Workers = cluster.Shoot.Spec.Provider.Workers[:1]
+
+			Expect(getClusterAutoscalerPriorityExpanderChartValues(cluster)).To(Equal(map[string]any{
+				"enabled": false,
+			}))
+		})
+	})
 })
 
 func encode(obj runtime.Object) []byte {
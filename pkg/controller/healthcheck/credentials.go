@@ -0,0 +1,235 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
+	"github.com/go-logr/logr"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+const (
+	// ConditionTypeCredentialsValid is the type of the ControlPlane condition which reports whether the credentials of
+	// the cloudprovider secret are valid for the metal API.
+	ConditionTypeCredentialsValid = "CredentialsValid"
+
+	// credentialsExpirationWarningPeriod is the period before the expiration of the credentials in which the
+	// credentials health check reports the credentials as expiring.
+	credentialsExpirationWarningPeriod = 7 * 24 * time.Hour
+)
+
+// requiredMetalPermissions are the permissions in the metal namespace which are required to manage the machines of a
+// shoot.
+var requiredMetalPermissions = []authorizationv1.ResourceAttributes{
+	{Group: "metal.ironcore.dev", Resource: "serverclaims", Verb: "create"},
+	{Group: "metal.ironcore.dev", Resource: "serverclaims", Verb: "delete"},
+	{Group: "", Resource: "secrets", Verb: "create"},
+}
+
+// CredentialsHealthChecker checks whether the credentials of the cloudprovider secret are accepted by the metal API,
+// grant the required permissions and are not about to expire.
+type CredentialsHealthChecker struct {
+	logger     logr.Logger
+	seedClient client.Client
+
+	now            func() time.Time
+	newMetalClient func(secret *corev1.Secret) (client.Client, string, error)
+}
+
+// NewCredentialsHealthChecker is a healthCheck function to check the credentials of the cloudprovider secret.
+func NewCredentialsHealthChecker() healthcheck.HealthCheck {
+	return &CredentialsHealthChecker{
+		now:            time.Now,
+		newMetalClient: metal.GetMetalClientAndNamespaceFromSecret,
+	}
+}
+
+// InjectSeedClient injects the seed client
+func (healthChecker *CredentialsHealthChecker) InjectSeedClient(seedClient client.Client) {
+	healthChecker.seedClient = seedClient
+}
+
+// SetLoggerSuffix injects the logger
+func (healthChecker *CredentialsHealthChecker) SetLoggerSuffix(provider, extension string) {
+	healthChecker.logger = log.Log.WithName(fmt.Sprintf("%s-%s-healthcheck-credentials", provider, extension))
+}
+
+// DeepCopy clones the healthCheck struct by making a copy and returning the pointer to that new copy
+func (healthChecker *CredentialsHealthChecker) DeepCopy() healthcheck.HealthCheck {
+	shallowCopy := *healthChecker
+	return &shallowCopy
+}
+
+// Check executes the health check
+func (healthChecker *CredentialsHealthChecker) Check(ctx context.Context, request types.NamespacedName) (*healthcheck.SingleCheckResult, error) {
+	secret := &corev1.Secret{}
+	if err := healthChecker.seedClient.Get(ctx, client.ObjectKey{Namespace: request.Namespace, Name: v1beta1constants.SecretNameCloudProvider}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get cloudprovider secret: %w", err)
+	}
+
+	expiration, err := credentialsExpiration(secret.Data[metal.KubeConfigFieldName])
+	if err != nil {
+		return nil, err
+	}
+	now := healthChecker.now()
+	if expiration != nil && !expiration.After(now) {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionFalse,
+			Detail: fmt.Sprintf("CredentialsExpired: the credentials of the cloudprovider secret expired at %s", expiration.UTC().Format(time.RFC3339)),
+			Codes:  []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthenticated},
+		}, nil
+	}
+
+	metalClient, namespace, err := healthChecker.newMetalClient(secret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create metal client from cloudprovider secret: %w", err)
+	}
+
+	var missingPermissions []string
+	for _, permission := range requiredMetalPermissions {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Group:     permission.Group,
+					Resource:  permission.Resource,
+					Verb:      permission.Verb,
+				},
+			},
+		}
+		if err := metalClient.Create(ctx, review); err != nil {
+			if apierrors.IsUnauthorized(err) {
+				return &healthcheck.SingleCheckResult{
+					Status: gardencorev1beta1.ConditionFalse,
+					Detail: fmt.Sprintf("CredentialsInvalid: the credentials of the cloudprovider secret are not accepted by the metal API: %v", err),
+					Codes:  []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthenticated},
+				}, nil
+			}
+			if apierrors.IsForbidden(err) {
+				return &healthcheck.SingleCheckResult{
+					Status: gardencorev1beta1.ConditionFalse,
+					Detail: fmt.Sprintf("CredentialsUnauthorized: the credentials of the cloudprovider secret are not allowed to review their permissions: %v", err),
+					Codes:  []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthorized},
+				}, nil
+			}
+			return nil, fmt.Errorf("failed to review permissions of the cloudprovider secret: %w", err)
+		}
+		if !review.Status.Allowed {
+			missingPermissions = append(missingPermissions, permissionString(permission))
+		}
+	}
+	if len(missingPermissions) > 0 {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionFalse,
+			Detail: fmt.Sprintf("CredentialsUnauthorized: the credentials of the cloudprovider secret are missing permissions in metal namespace %q: %s", namespace, strings.Join(missingPermissions, ", ")),
+			Codes:  []gardencorev1beta1.ErrorCode{gardencorev1beta1.ErrorInfraUnauthorized},
+		}, nil
+	}
+
+	// Workload identity tokens are short-lived and renewed automatically, hence they are only reported once expired.
+	isWorkloadIdentity := secret.Labels[securityv1alpha1constants.LabelPurpose] == securityv1alpha1constants.LabelPurposeWorkloadIdentityTokenRequestor
+	if expiration != nil && !isWorkloadIdentity && expiration.Sub(now) < credentialsExpirationWarningPeriod {
+		return &healthcheck.SingleCheckResult{
+			Status: gardencorev1beta1.ConditionProgressing,
+			Detail: fmt.Sprintf("CredentialsExpiring: the credentials of the cloudprovider secret expire at %s", expiration.UTC().Format(time.RFC3339)),
+			// The condition is progressing for at most the warning period before the credentials are reported as expired.
+			ProgressingThreshold: ptr.To(credentialsExpirationWarningPeriod),
+		}, nil
+	}
+
+	return &healthcheck.SingleCheckResult{
+		Status: gardencorev1beta1.ConditionTrue,
+	}, nil
+}
+
+// credentialsExpiration returns the expiration time of the credentials in the given kubeconfig. It is nil if the
+// credentials do not expire or their expiration cannot be determined, e.g. for opaque tokens.
+func credentialsExpiration(kubeconfig []byte) (*time.Time, error) {
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("could not find a kubeconfig in the cloudprovider secret")
+	}
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig of the cloudprovider secret: %w", err)
+	}
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return nil, fmt.Errorf("could not find the current context %q in the kubeconfig of the cloudprovider secret", config.CurrentContext)
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return nil, fmt.Errorf("could not find the user %q in the kubeconfig of the cloudprovider secret", kubeContext.AuthInfo)
+	}
+
+	switch {
+	case authInfo.Token != "":
+		return jwtExpiration(authInfo.Token), nil
+	case len(authInfo.ClientCertificateData) > 0:
+		block, _ := pem.Decode(authInfo.ClientCertificateData)
+		if block == nil {
+			return nil, nil
+		}
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, nil
+		}
+		return &certificate.NotAfter, nil
+	default:
+		return nil, nil
+	}
+}
+
+// jwtExpiration returns the time of the `exp` claim of the given token or nil if the token is not a JWT or has no
+// `exp` claim. The signature of the token is not verified.
+func jwtExpiration(token string) *time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil
+	}
+	claims := struct {
+		Exp *json.Number `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == nil {
+		return nil
+	}
+	exp, err := claims.Exp.Int64()
+	if err != nil {
+		return nil
+	}
+	expiration := time.Unix(exp, 0)
+	return &expiration
+}
+
+func permissionString(permission authorizationv1.ResourceAttributes) string {
+	resource := permission.Resource
+	if permission.Group != "" {
+		resource += "." + permission.Group
+	}
+	return permission.Verb + " " + resource
+}
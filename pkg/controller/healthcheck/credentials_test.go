@@ -0,0 +1,231 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package healthcheck
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/gardener/gardener/extensions/pkg/controller/healthcheck"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/util/cert"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("CredentialsHealthChecker", func() {
+	const shootNamespace = "shoot--foo--bar"
+
+	var (
+		ctx = context.TODO()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		secret  *corev1.Secret
+		checker *CredentialsHealthChecker
+
+		deniedVerbs []string
+		reviewErr   error
+		reviews     []*authorizationv1.SelfSubjectAccessReview
+	)
+
+	jwt := func(exp time.Time) string {
+		encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+		return encode(`{"alg":"RS256"}`) + "." + encode(fmt.Sprintf(`{"sub":"metal","exp":%d}`, exp.Unix())) + ".signature"
+	}
+
+	kubeconfig := func(authInfo *clientcmdapi.AuthInfo) []byte {
+		config := clientcmdapi.NewConfig()
+		config.Clusters["metal"] = &clientcmdapi.Cluster{Server: "https://metal.example.com"}
+		config.AuthInfos["user"] = authInfo
+		config.Contexts["metal"] = &clientcmdapi.Context{Cluster: "metal", AuthInfo: "user", Namespace: "foo"}
+		config.CurrentContext = "metal"
+		raw, err := clientcmd.Write(*config)
+		Expect(err).NotTo(HaveOccurred())
+		return raw
+	}
+
+	BeforeEach(func() {
+		deniedVerbs = nil
+		reviewErr = nil
+		reviews = nil
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "cloudprovider", Namespace: shootNamespace},
+			Data: map[string][]byte{
+				"namespace":  []byte("foo"),
+				"kubeconfig": kubeconfig(&clientcmdapi.AuthInfo{Token: "opaque-token"}),
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		metalClient := fakeclient.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+				review, ok := obj.(*authorizationv1.SelfSubjectAccessReview)
+				Expect(ok).To(BeTrue())
+				if reviewErr != nil {
+					return reviewErr
+				}
+				reviews = append(reviews, review)
+				review.Status.Allowed = true
+				for _, verb := range deniedVerbs {
+					if review.Spec.ResourceAttributes.Verb == verb {
+						review.Status.Allowed = false
+					}
+				}
+				return nil
+			},
+		}).Build()
+
+		checker = NewCredentialsHealthChecker().(*CredentialsHealthChecker)
+		checker.now = func() time.Time { return now }
+		checker.newMetalClient = func(s *corev1.Secret) (client.Client, string, error) {
+			return metalClient, string(s.Data["namespace"]), nil
+		}
+	})
+
+	checkWithError := func() (*healthcheck.SingleCheckResult, error) {
+		checker.InjectSeedClient(fakeclient.NewClientBuilder().WithObjects(secret).Build())
+		return checker.Check(ctx, types.NamespacedName{Namespace: shootNamespace, Name: "foo"})
+	}
+
+	check := func() *healthcheck.SingleCheckResult {
+		result, err := checkWithError()
+		Expect(err).NotTo(HaveOccurred())
+		return result
+	}
+
+	It("should succeed if the credentials are valid and have all permissions", func() {
+		result := check()
+
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionTrue))
+		Expect(reviews).To(HaveLen(len(requiredMetalPermissions)))
+		for _, review := range reviews {
+			Expect(review.Spec.ResourceAttributes.Namespace).To(Equal("foo"))
+		}
+	})
+
+	It("should fail if the cloudprovider secret has no kubeconfig", func() {
+		delete(secret.Data, "kubeconfig")
+
+		_, err := checkWithError()
+		Expect(err).To(MatchError(ContainSubstring("could not find a kubeconfig")))
+	})
+
+	It("should report expired tokens without contacting the metal API", func() {
+		secret.Data["kubeconfig"] = kubeconfig(&clientcmdapi.AuthInfo{Token: jwt(now.Add(-time.Minute))})
+
+		result := check()
+
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(result.Detail).To(HavePrefix("CredentialsExpired:"))
+		Expect(result.Codes).To(ConsistOf(gardencorev1beta1.ErrorInfraUnauthenticated))
+		Expect(reviews).To(BeEmpty())
+	})
+
+	It("should report expiring tokens as progressing", func() {
+		secret.Data["kubeconfig"] = kubeconfig(&clientcmdapi.AuthInfo{Token: jwt(now.Add(48 * time.Hour))})
+
+		result := check()
+
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionProgressing))
+		Expect(result.Detail).To(Equal("CredentialsExpiring: the credentials of the cloudprovider secret expire at 2025-01-03T00:00:00Z"))
+		Expect(result.ProgressingThreshold).To(Equal(ptr.To(credentialsExpirationWarningPeriod)))
+	})
+
+	It("should not report expiring workload identity tokens", func() {
+		secret.Labels = map[string]string{"security.gardener.cloud/purpose": "workload-identity-token-requestor"}
+		secret.Data["kubeconfig"] = kubeconfig(&clientcmdapi.AuthInfo{Token: jwt(now.Add(time.Hour))})
+
+		Expect(check().Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should succeed for tokens which expire after the warning period", func() {
+		secret.Data["kubeconfig"] = kubeconfig(&clientcmdapi.AuthInfo{Token: jwt(now.Add(30 * 24 * time.Hour))})
+
+		Expect(check().Status).To(Equal(gardencorev1beta1.ConditionTrue))
+	})
+
+	It("should report rejected credentials", func() {
+		reviewErr = apierrors.NewUnauthorized("token revoked")
+
+		result := check()
+
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(result.Detail).To(HavePrefix("CredentialsInvalid:"))
+		Expect(result.Codes).To(ConsistOf(gardencorev1beta1.ErrorInfraUnauthenticated))
+	})
+
+	It("should report credentials which may not review their permissions", func() {
+		reviewErr = apierrors.NewForbidden(schema.GroupResource{Group: "authorization.k8s.io", Resource: "selfsubjectaccessreviews"}, "", fmt.Errorf("denied"))
+
+		result := check()
+
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(result.Detail).To(HavePrefix("CredentialsUnauthorized:"))
+		Expect(result.Codes).To(ConsistOf(gardencorev1beta1.ErrorInfraUnauthorized))
+	})
+
+	It("should report missing permissions", func() {
+		deniedVerbs = []string{"delete"}
+
+		result := check()
+
+		Expect(result.Status).To(Equal(gardencorev1beta1.ConditionFalse))
+		Expect(result.Detail).To(Equal(`CredentialsUnauthorized: the credentials of the cloudprovider secret are missing permissions in metal namespace "foo": delete serverclaims.metal.ironcore.dev`))
+		Expect(result.Codes).To(ConsistOf(gardencorev1beta1.ErrorInfraUnauthorized))
+	})
+
+	It("should return an error if the permissions cannot be reviewed", func() {
+		reviewErr = fmt.Errorf("connection refused")
+
+		_, err := checkWithError()
+		Expect(err).To(MatchError(ContainSubstring("connection refused")))
+	})
+
+	Describe("#credentialsExpiration", func() {
+		It("should return nil for opaque tokens", func() {
+			Expect(credentialsExpiration(kubeconfig(&clientcmdapi.AuthInfo{Token: "opaque-token"}))).To(BeNil())
+		})
+
+		It("should return nil for tokens without exp claim", func() {
+			token := base64.RawURLEncoding.EncodeToString([]byte(`{}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"metal"}`)) + ".signature"
+			Expect(credentialsExpiration(kubeconfig(&clientcmdapi.AuthInfo{Token: token}))).To(BeNil())
+		})
+
+		It("should return the exp claim of JWTs", func() {
+			expiration, err := credentialsExpiration(kubeconfig(&clientcmdapi.AuthInfo{Token: jwt(now)}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expiration).NotTo(BeNil())
+			Expect(*expiration).To(BeTemporally("==", now))
+		})
+
+		It("should return the expiration of client certificates", func() {
+			clientCertificate, clientKey, err := cert.GenerateSelfSignedCertKey("metal-user", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			certificates, err := cert.ParseCertsPEM(clientCertificate)
+			Expect(err).NotTo(HaveOccurred())
+
+			expiration, err := credentialsExpiration(kubeconfig(&clientcmdapi.AuthInfo{ClientCertificateData: clientCertificate, ClientKeyData: clientKey}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expiration).NotTo(BeNil())
+			Expect(*expiration).To(BeTemporally("==", certificates[0].NotAfter))
+		})
+	})
+})
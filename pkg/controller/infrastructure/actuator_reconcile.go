@@ -26,16 +26,19 @@ func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, infra *extens
 
 	originalInfra := infra.DeepCopy()
 
-	var newNodes []string
+	if infra.Status.Networking == nil {
+		infra.Status.Networking = &extensionsv1alpha1.InfrastructureStatusNetworking{}
+	}
+
 	if infrastructureConfig.Networks != nil {
+		var newNodes []string
 		for _, network := range infrastructureConfig.Networks {
 			if network.Name == "" {
 				return fmt.Errorf("network name is required")
 			}
+			// The nodes of dual-stack networks get a range of each IP family.
 			newNodes = append(newNodes, network.CIDR)
-		}
-		if infra.Status.Networking == nil {
-			infra.Status.Networking = &extensionsv1alpha1.InfrastructureStatusNetworking{}
+			newNodes = append(newNodes, network.AdditionalCIDRs...)
 		}
 		infra.Status.Networking.Nodes = newNodes
 	}
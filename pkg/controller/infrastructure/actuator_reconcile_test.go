@@ -81,6 +81,20 @@ var _ = Describe("Actuator Reconcile", func() {
 			Expect(infra.Status.Networking.Nodes).To(Equal(expectedNodes))
 		})
 
+		It("should add the ranges of all IP families of dual-stack networks to infra.Status.Networking.Nodes", func(ctx SpecContext) {
+			infrastructureConfigRaw, err := json.Marshal(metalv1alpha1.InfrastructureConfig{
+				Networks: []metalv1alpha1.Networks{
+					{Name: "worker-network-1", CIDR: "10.10.10.0/24", AdditionalCIDRs: []string{"2001:db8:1::/64"}},
+					{Name: "worker-network-2", CIDR: "10.10.20.0/24", AdditionalCIDRs: []string{"2001:db8:2::/64"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			infra.Spec.ProviderConfig.Raw = infrastructureConfigRaw
+
+			Expect(act.Reconcile(ctx, log, infra, cluster)).To(Succeed())
+			Expect(infra.Status.Networking.Nodes).To(Equal([]string{"10.10.10.0/24", "2001:db8:1::/64", "10.10.20.0/24", "2001:db8:2::/64"}))
+		})
+
 		It("should copy the Pod and Service CIDRs from the Shoot spec to infra.Status.Networking", func(ctx SpecContext) {
 			err := act.Reconcile(ctx, log, infra, cluster)
 			Expect(err).NotTo(HaveOccurred())
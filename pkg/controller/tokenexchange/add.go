@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenexchange
+
+import (
+	"context"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+// ControllerName is the name of the controller which exchanges the workload identity tokens of cloudprovider secrets
+// for metal cluster tokens.
+const ControllerName = "metal-token-exchange"
+
+var (
+	// DefaultAddOptions are the default AddOptions for AddToManager.
+	DefaultAddOptions = AddOptions{}
+)
+
+// AddOptions are options to apply when adding the token exchange controller to the manager.
+type AddOptions struct {
+	// Controller are the controller.Options.
+	Controller controller.Options
+}
+
+// AddToManagerWithOptions adds a controller with the given Options to the given manager.
+func AddToManagerWithOptions(_ context.Context, mgr manager.Manager, opts AddOptions) error {
+	r := &reconciler{
+		client:        mgr.GetClient(),
+		log:           mgr.GetLogger().WithName(ControllerName),
+		now:           time.Now,
+		exchangeToken: metal.ExchangeToken,
+	}
+
+	return builder.ControllerManagedBy(mgr).
+		Named(ControllerName).
+		WithOptions(opts.Controller).
+		For(&corev1.Secret{}, builder.WithPredicates(workloadIdentitySecretPredicate())).
+		Complete(r)
+}
+
+// AddToManager adds a controller with the default Options.
+func AddToManager(ctx context.Context, mgr manager.Manager) error {
+	return AddToManagerWithOptions(ctx, mgr, DefaultAddOptions)
+}
+
+// workloadIdentitySecretPredicate only lets cloudprovider secrets pass which request workload identity tokens for
+// the metal cluster.
+func workloadIdentitySecretPredicate() predicate.Predicate {
+	return predicate.NewPredicateFuncs(func(obj client.Object) bool {
+		labels := obj.GetLabels()
+		return obj.GetName() == v1beta1constants.SecretNameCloudProvider &&
+			labels[securityv1alpha1constants.LabelPurpose] == securityv1alpha1constants.LabelPurposeWorkloadIdentityTokenRequestor &&
+			labels[securityv1alpha1constants.LabelWorkloadIdentityProvider] == metal.Type
+	})
+}
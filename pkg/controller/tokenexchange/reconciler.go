@@ -0,0 +1,141 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenexchange
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+const (
+	// renewFraction is the fraction of the lifetime of an exchanged token after which it is renewed.
+	renewFraction = 0.8
+	// defaultRenewPeriod is the period after which exchanged tokens are renewed if neither the token exchange endpoint
+	// reports their lifetime nor the workload identity token has a renew timestamp.
+	defaultRenewPeriod = time.Hour
+)
+
+// reconciler exchanges the workload identity token of cloudprovider secrets for a metal cluster token at the token
+// exchange endpoint of their WorkloadIdentityConfig. The exchanged token is stored in the secret, from which the
+// cloudprovider webhook builds the kubeconfig, and renewed before it expires.
+type reconciler struct {
+	client        client.Client
+	log           logr.Logger
+	now           func() time.Time
+	exchangeToken func(ctx context.Context, config *apismetal.TokenExchangeConfig, proxyURL string, subjectToken string) (*metal.ExchangedToken, error)
+}
+
+// Reconcile exchanges the workload identity token of the given cloudprovider secret if the exchanged token is missing
+// or has to be renewed.
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	secret := &corev1.Secret{}
+	if err := r.client.Get(ctx, req.NamespacedName, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get secret %s: %w", req.NamespacedName, err)
+	}
+
+	workloadIdentityConfig, err := helper.WorkloadIdentityConfigFromBytes(secret.Data[securityv1alpha1constants.DataKeyConfig])
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// The token is written by the token requestor after the secret has been created, which triggers a reconciliation.
+	token := secret.Data[securityv1alpha1constants.DataKeyToken]
+	if workloadIdentityConfig.TokenExchange == nil || len(token) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	now := r.now()
+	if renewTimestamp, ok := parseTimestamp(secret.Annotations[metal.TokenExchangeRenewTimestampAnnotation]); ok &&
+		len(secret.Data[metal.ExchangedTokenFieldName]) > 0 && now.Before(renewTimestamp) {
+		return reconcile.Result{RequeueAfter: renewTimestamp.Sub(now)}, nil
+	}
+
+	proxyURL, err := r.regionProxyURL(ctx, secret.Namespace)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	exchangedToken, err := r.exchangeToken(ctx, workloadIdentityConfig.TokenExchange, proxyURL, string(token))
+	if err != nil {
+		return reconcile.Result{}, fmt.Errorf("could not exchange workload identity token: %w", err)
+	}
+
+	renewTimestamp := r.renewTimestamp(secret, exchangedToken, now)
+	r.log.Info("Exchanged workload identity token", "secret", req.NamespacedName, "renewTimestamp", renewTimestamp)
+
+	patch := client.MergeFrom(secret.DeepCopy())
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[metal.ExchangedTokenFieldName] = []byte(exchangedToken.Token)
+	metav1.SetMetaDataAnnotation(&secret.ObjectMeta, metal.TokenExchangeRenewTimestampAnnotation, renewTimestamp.Format(time.RFC3339))
+	if err := r.client.Patch(ctx, secret, patch); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to store exchanged token in secret %s: %w", req.NamespacedName, err)
+	}
+
+	return reconcile.Result{RequeueAfter: renewTimestamp.Sub(now)}, nil
+}
+
+// renewTimestamp returns the time after which the given exchanged token has to be renewed. Tokens without reported
+// lifetime are renewed together with the workload identity token.
+func (r *reconciler) renewTimestamp(secret *corev1.Secret, exchangedToken *metal.ExchangedToken, now time.Time) time.Time {
+	if exchangedToken.ExpiresIn > 0 {
+		return now.Add(time.Duration(float64(exchangedToken.ExpiresIn) * renewFraction))
+	}
+	if renewTimestamp, ok := parseTimestamp(secret.Annotations[securityv1alpha1constants.AnnotationWorkloadIdentityTokenRenewTimestamp]); ok && renewTimestamp.After(now) {
+		return renewTimestamp
+	}
+	return now.Add(defaultRenewPeriod)
+}
+
+// regionProxyURL returns the proxy URL of the region of the shoot in the given namespace, through which the token
+// exchange endpoint is reached as well.
+func (r *reconciler) regionProxyURL(ctx context.Context, namespace string) (string, error) {
+	cluster, err := extensionscontroller.GetCluster(ctx, r.client, namespace)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster: %w", err)
+	}
+	if cluster.Shoot == nil {
+		return "", nil
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return "", err
+	}
+	if cloudProfileConfig == nil {
+		return "", nil
+	}
+
+	for _, region := range cloudProfileConfig.RegionConfigs {
+		if region.Name == cluster.Shoot.Spec.Region {
+			return region.ProxyURL, nil
+		}
+	}
+	return "", nil
+}
+
+func parseTimestamp(value string) (time.Time, bool) {
+	if value == "" {
+		return time.Time{}, false
+	}
+	timestamp, err := time.Parse(time.RFC3339, value)
+	return timestamp, err == nil
+}
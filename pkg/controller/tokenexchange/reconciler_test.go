@@ -0,0 +1,230 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package tokenexchange
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Reconciler", func() {
+	const namespace = "shoot--foo--bar"
+
+	var (
+		ctx  context.Context
+		now  time.Time
+		c    client.Client
+		r    *reconciler
+		req  reconcile.Request
+		sent struct {
+			config       *apismetal.TokenExchangeConfig
+			proxyURL     string
+			subjectToken string
+			calls        int
+		}
+		exchangedToken *metal.ExchangedToken
+		exchangeErr    error
+		secret         *corev1.Secret
+	)
+
+	newWorkloadIdentityConfig := func(tokenExchange *apiv1alpha1.TokenExchangeConfig) []byte {
+		data, err := json.Marshal(&apiv1alpha1.WorkloadIdentityConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "WorkloadIdentityConfig",
+			},
+			Namespace:     "metal",
+			TokenExchange: tokenExchange,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		return data
+	}
+
+	newCluster := func() *extensionsv1alpha1.Cluster {
+		cloudProfileConfig, err := json.Marshal(&apiv1alpha1.CloudProfileConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "CloudProfileConfig",
+			},
+			RegionConfigs: []apiv1alpha1.RegionConfig{
+				{Name: "other-region", ProxyURL: "http://other-proxy:3128"},
+				{Name: "region", ProxyURL: "http://proxy:3128"},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		cloudProfile, err := json.Marshal(&gardencorev1beta1.CloudProfile{
+			Spec: gardencorev1beta1.CloudProfileSpec{
+				ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+			},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		shoot, err := json.Marshal(&gardencorev1beta1.Shoot{
+			Spec: gardencorev1beta1.ShootSpec{Region: "region"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: namespace},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				CloudProfile: runtime.RawExtension{Raw: cloudProfile},
+				Seed:         runtime.RawExtension{Raw: []byte("{}")},
+				Shoot:        runtime.RawExtension{Raw: shoot},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.TODO()
+		now = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(extensionsv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1beta1constants.SecretNameCloudProvider,
+				Namespace: namespace,
+				Labels: map[string]string{
+					securityv1alpha1constants.LabelPurpose:                  securityv1alpha1constants.LabelPurposeWorkloadIdentityTokenRequestor,
+					securityv1alpha1constants.LabelWorkloadIdentityProvider: metal.Type,
+				},
+			},
+			Data: map[string][]byte{
+				securityv1alpha1constants.DataKeyConfig: newWorkloadIdentityConfig(&apiv1alpha1.TokenExchangeConfig{URL: "https://sts.example.com/token", Audience: "metal"}),
+				securityv1alpha1constants.DataKeyToken:  []byte("workload-identity-token"),
+			},
+		}
+		c = fakeclient.NewClientBuilder().WithScheme(scheme).WithObjects(newCluster()).Build()
+
+		sent.config, sent.proxyURL, sent.subjectToken, sent.calls = nil, "", "", 0
+		exchangedToken = &metal.ExchangedToken{Token: "metal-token", ExpiresIn: time.Hour}
+		exchangeErr = nil
+
+		r = &reconciler{
+			client: c,
+			log:    logr.Discard(),
+			now:    func() time.Time { return now },
+			exchangeToken: func(_ context.Context, config *apismetal.TokenExchangeConfig, proxyURL string, subjectToken string) (*metal.ExchangedToken, error) {
+				sent.config, sent.proxyURL, sent.subjectToken = config, proxyURL, subjectToken
+				sent.calls++
+				return exchangedToken, exchangeErr
+			},
+		}
+		req = reconcile.Request{NamespacedName: types.NamespacedName{Namespace: namespace, Name: v1beta1constants.SecretNameCloudProvider}}
+	})
+
+	Describe("#workloadIdentitySecretPredicate", func() {
+		It("should only accept metal workload identity cloudprovider secrets", func() {
+			p := workloadIdentitySecretPredicate()
+			Expect(p.Create(event.CreateEvent{Object: secret})).To(BeTrue())
+
+			otherProvider := secret.DeepCopy()
+			otherProvider.Labels[securityv1alpha1constants.LabelWorkloadIdentityProvider] = "aws"
+			Expect(p.Create(event.CreateEvent{Object: otherProvider})).To(BeFalse())
+
+			otherName := secret.DeepCopy()
+			otherName.Name = "other"
+			Expect(p.Create(event.CreateEvent{Object: otherName})).To(BeFalse())
+
+			staticCredentials := secret.DeepCopy()
+			staticCredentials.Labels = nil
+			Expect(p.Create(event.CreateEvent{Object: staticCredentials})).To(BeFalse())
+		})
+	})
+
+	Describe("#Reconcile", func() {
+		It("should exchange the token through the region proxy and renew it before it expires", func() {
+			Expect(c.Create(ctx, secret)).To(Succeed())
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(48 * time.Minute))
+
+			Expect(sent.config).To(Equal(&apismetal.TokenExchangeConfig{URL: "https://sts.example.com/token", Audience: "metal"}))
+			Expect(sent.proxyURL).To(Equal("http://proxy:3128"))
+			Expect(sent.subjectToken).To(Equal("workload-identity-token"))
+
+			Expect(c.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue(metal.ExchangedTokenFieldName, []byte("metal-token")))
+			Expect(secret.Annotations).To(HaveKeyWithValue(metal.TokenExchangeRenewTimestampAnnotation, "2024-01-01T12:48:00Z"))
+		})
+
+		It("should not exchange the token again before the renew timestamp", func() {
+			Expect(c.Create(ctx, secret)).To(Succeed())
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			now = now.Add(40 * time.Minute)
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(8 * time.Minute))
+			Expect(sent.calls).To(Equal(1))
+
+			now = now.Add(8 * time.Minute)
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sent.calls).To(Equal(2))
+		})
+
+		It("should renew tokens without lifetime together with the workload identity token", func() {
+			metav1.SetMetaDataAnnotation(&secret.ObjectMeta, securityv1alpha1constants.AnnotationWorkloadIdentityTokenRenewTimestamp, "2024-01-01T14:00:00Z")
+			Expect(c.Create(ctx, secret)).To(Succeed())
+			exchangedToken = &metal.ExchangedToken{Token: "metal-token"}
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(2 * time.Hour))
+		})
+
+		It("should not exchange anything if no token exchange is configured", func() {
+			secret.Data[securityv1alpha1constants.DataKeyConfig] = newWorkloadIdentityConfig(nil)
+			Expect(c.Create(ctx, secret)).To(Succeed())
+
+			Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{}))
+			Expect(sent.calls).To(BeZero())
+		})
+
+		It("should wait for the workload identity token", func() {
+			delete(secret.Data, securityv1alpha1constants.DataKeyToken)
+			Expect(c.Create(ctx, secret)).To(Succeed())
+
+			Expect(r.Reconcile(ctx, req)).To(Equal(reconcile.Result{}))
+			Expect(sent.calls).To(BeZero())
+		})
+
+		It("should fail and keep the secret unchanged if the exchange fails", func() {
+			Expect(c.Create(ctx, secret)).To(Succeed())
+			exchangeErr = errors.New("token exchange failed with status 400")
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).To(MatchError(ContainSubstring("could not exchange workload identity token")))
+
+			Expect(c.Get(ctx, req.NamespacedName, secret)).To(Succeed())
+			Expect(secret.Data).NotTo(HaveKey(metal.ExchangedTokenFieldName))
+		})
+	})
+})
@@ -5,15 +5,15 @@ package worker
 
 import (
 	"context"
+	"time"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
 	"github.com/gardener/gardener/extensions/pkg/controller/worker/genericactuator"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
-	"k8s.io/client-go/kubernetes"
-	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -26,7 +26,6 @@ type delegateFactory struct {
 	gardenReader client.Reader
 	seedClient   client.Client
 	decoder      runtime.Decoder
-	restConfig   *rest.Config
 	scheme       *runtime.Scheme
 }
 
@@ -41,7 +40,6 @@ func NewActuator(mgr manager.Manager, gardenCluster cluster.Cluster) worker.Actu
 		gardenReader: gardenCluster.GetAPIReader(),
 		seedClient:   mgr.GetClient(),
 		decoder:      serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
-		restConfig:   mgr.GetConfig(),
 		scheme:       mgr.GetScheme(),
 	}
 
@@ -51,22 +49,46 @@ func NewActuator(mgr manager.Manager, gardenCluster cluster.Cluster) worker.Actu
 	}
 }
 
-func (d *delegateFactory) WorkerDelegate(_ context.Context, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) (genericactuator.WorkerDelegate, error) {
-	clientset, err := kubernetes.NewForConfig(d.restConfig)
-	if err != nil {
-		return nil, err
+// Reconcile reconciles the Worker and records the duration of the reconciliation.
+func (a *actuator) Reconcile(ctx context.Context, log logr.Logger, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) error {
+	defer observeReconcilePhase(reconcilePhaseTotal, time.Now())
+	return a.Actuator.Reconcile(ctx, log, worker, cluster)
+}
+
+// Delete deletes the Worker and the metrics of its shoot.
+func (a *actuator) Delete(ctx context.Context, log logr.Logger, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) error {
+	if err := a.Actuator.Delete(ctx, log, worker, cluster); err != nil {
+		return err
 	}
+	deleteShootMetrics(worker.Namespace)
+	return nil
+}
 
-	serverVersion, err := clientset.Discovery().ServerVersion()
-	if err != nil {
-		return nil, err
+// ForceDelete forcefully deletes the Worker and the metrics of its shoot.
+func (a *actuator) ForceDelete(ctx context.Context, log logr.Logger, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) error {
+	if err := a.Actuator.ForceDelete(ctx, log, worker, cluster); err != nil {
+		return err
 	}
+	deleteShootMetrics(worker.Namespace)
+	return nil
+}
+
+// Migrate migrates the Worker and deletes the metrics of its shoot, which is reconciled by another seed afterwards.
+func (a *actuator) Migrate(ctx context.Context, log logr.Logger, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) error {
+	if err := a.Actuator.Migrate(ctx, log, worker, cluster); err != nil {
+		return err
+	}
+	deleteShootMetrics(worker.Namespace)
+	return nil
+}
 
+// WorkerDelegate creates a new delegate for the given Worker. It does not issue any requests to the seed, so that
+// reconcile storms, e.g. after a seed restart, do not put additional load on the seed API server.
+func (d *delegateFactory) WorkerDelegate(_ context.Context, worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster) (genericactuator.WorkerDelegate, error) {
 	return NewWorkerDelegate(
 		d.seedClient,
 		d.decoder,
 		d.scheme,
-		serverVersion.GitVersion,
 		worker,
 		cluster,
 	)
@@ -77,10 +99,17 @@ type workerDelegate struct {
 	decoder runtime.Decoder
 	scheme  *runtime.Scheme
 
-	serverVersion      string
 	cloudProfileConfig *api.CloudProfileConfig
 	cluster            *extensionscontroller.Cluster
 	worker             *extensionsv1alpha1.Worker
+
+	// mergedIgnitions caches the merged extra ignitions by worker pool and zone, see mergedIgnitionConfig.
+	mergedIgnitions map[string]mergedIgnition
+}
+
+type mergedIgnition struct {
+	config string
+	err    error
 }
 
 // NewWorkerDelegate creates a new context for a worker reconciliation.
@@ -88,7 +117,6 @@ func NewWorkerDelegate(
 	client client.Client,
 	decoder runtime.Decoder,
 	scheme *runtime.Scheme,
-	serverVersion string,
 	worker *extensionsv1alpha1.Worker,
 	cluster *extensionscontroller.Cluster,
 ) (
@@ -104,7 +132,6 @@ func NewWorkerDelegate(
 		scheme:             scheme,
 		client:             client,
 		decoder:            decoder,
-		serverVersion:      serverVersion,
 		cloudProfileConfig: config,
 		cluster:            cluster,
 		worker:             worker,
@@ -0,0 +1,185 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinescheme "github.com/gardener/machine-controller-manager/pkg/client/clientset/versioned/scheme"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/install"
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+)
+
+// BenchmarkWorkerReconcile measures the cost of generating the machine deployments and deploying the machine classes
+// when many Workers are reconciled at once, e.g. after a seed restart. The fake client stores applied objects as is.
+func BenchmarkWorkerReconcile(b *testing.B) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	if err := machinescheme.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+	install.Install(scheme)
+
+	cloudProfileConfigJSON, err := json.Marshal(&apiv1alpha1.CloudProfileConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+			Kind:       "CloudProfileConfig",
+		},
+		MachineTypes: []apiv1alpha1.MachineType{{Name: "large", ServerLabels: map[string]string{"foo": "bar"}}},
+		MachineImages: []apiv1alpha1.MachineImages{{
+			Name: "my-os",
+			Versions: []apiv1alpha1.MachineImageVersion{{
+				Version:      "1.0",
+				Image:        "registry/my-os",
+				Architecture: ptr.To("amd64"),
+			}},
+		}},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	workerConfigJSON, err := json.Marshal(&apiv1alpha1.WorkerConfig{
+		ExtraIgnition: &apiv1alpha1.IgnitionConfig{
+			Raw:       "systemd:\n  units:\n  - name: foo.service\n    enabled: true\n",
+			SecretRef: "ignition",
+		},
+		Metadata: map[string]string{"foo": "bar"},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	cluster := &extensionscontroller.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "shoot--foo--bar"},
+		CloudProfile: &gardencorev1beta1.CloudProfile{
+			Spec: gardencorev1beta1.CloudProfileSpec{
+				MachineTypes: []gardencorev1beta1.MachineType{{
+					Name:   "large",
+					CPU:    resource.MustParse("64"),
+					Memory: resource.MustParse("512Gi"),
+				}},
+				ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfigJSON},
+			},
+		},
+		Shoot: &gardencorev1beta1.Shoot{
+			Spec: gardencorev1beta1.ShootSpec{
+				Kubernetes: gardencorev1beta1.Kubernetes{Version: "1.32.0"},
+				Resources: []gardencorev1beta1.NamedResourceReference{{
+					Name:        "ignition",
+					ResourceRef: autoscalingv1.CrossVersionObjectReference{Kind: "Secret", Name: "ignition", APIVersion: "v1"},
+				}},
+			},
+		},
+	}
+
+	for _, workers := range []int{1, 100, 1000} {
+		var (
+			workerObjects = make([]*extensionsv1alpha1.Worker, 0, workers)
+			secrets       = make([]client.Object, 0, 2*workers)
+		)
+		for i := range workers {
+			namespace := fmt.Sprintf("shoot--foo--bar%d", i)
+			workerObjects = append(workerObjects, &extensionsv1alpha1.Worker{
+				ObjectMeta: metav1.ObjectMeta{Name: "worker", Namespace: namespace},
+				Spec: extensionsv1alpha1.WorkerSpec{
+					Region:    "foo",
+					SecretRef: corev1.SecretReference{Name: "cloudprovider", Namespace: namespace},
+					Pools: []extensionsv1alpha1.WorkerPool{{
+						Name:         "pool",
+						MachineType:  "large",
+						MachineImage: extensionsv1alpha1.MachineImage{Name: "my-os", Version: "1.0"},
+						Maximum:      2,
+						UserDataSecretRef: corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "user-data"},
+							Key:                  "userData",
+						},
+						Zones:          []string{"zone1", "zone2"},
+						ProviderConfig: &runtime.RawExtension{Raw: workerConfigJSON},
+					}},
+				},
+			})
+			secrets = append(secrets,
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "user-data", Namespace: namespace},
+					Data:       map[string][]byte{"userData": []byte("some-data")},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.ReferencedResourcesPrefix + "ignition", Namespace: namespace},
+					Data:       map[string][]byte{"ignition": []byte("storage:\n  files:\n  - path: /etc/foo\n")},
+				},
+			)
+		}
+
+		seedClient := fakeclient.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(secrets...).
+			WithInterceptorFuncs(interceptor.Funcs{Patch: applyPatch}).
+			Build()
+		factory := &delegateFactory{
+			seedClient: seedClient,
+			decoder:    serializer.NewCodecFactory(scheme, serializer.EnableStrict).UniversalDecoder(),
+			scheme:     scheme,
+		}
+
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for b.Loop() {
+				for _, worker := range workerObjects {
+					delegate, err := factory.WorkerDelegate(ctx, worker, cluster)
+					if err != nil {
+						b.Fatal(err)
+					}
+					if err := delegate.DeployMachineClasses(ctx); err != nil {
+						b.Fatal(err)
+					}
+					if _, err := delegate.GenerateMachineDeployments(ctx); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
+
+// applyPatch stores the objects applied with server-side apply, which the fake client does not support.
+func applyPatch(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing := obj.DeepCopyObject().(client.Object)
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
+	extensionspredicate "github.com/gardener/gardener/extensions/pkg/predicate"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	machinev1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	apiextensionsscheme "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/scheme"
@@ -14,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cluster"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
@@ -51,14 +53,31 @@ func AddToManagerWithOptions(ctx context.Context, mgr manager.Manager, opts AddO
 		return err
 	}
 
-	return worker.Add(ctx, mgr, worker.AddArgs{
-		Actuator:               NewActuator(mgr, opts.GardenCluster),
-		ControllerOptions:      opts.Controller,
-		Predicates:             worker.DefaultPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
-		Type:                   metal.Type,
-		ExtensionClass:         opts.ExtensionClass,
-		AutonomousShootCluster: opts.AutonomousShootCluster,
-	})
+	if err := worker.Add(ctx, mgr, worker.AddArgs{
+		Actuator:                  NewActuator(mgr, opts.GardenCluster),
+		ControllerOptions:         opts.Controller,
+		Predicates:                workerPredicates(ctx, mgr, opts.IgnoreOperationAnnotation),
+		Type:                      metal.Type,
+		IgnoreOperationAnnotation: opts.IgnoreOperationAnnotation,
+		ExtensionClass:            opts.ExtensionClass,
+		AutonomousShootCluster:    opts.AutonomousShootCluster,
+	}); err != nil {
+		return err
+	}
+
+	return addIgnitionSecretControllerToManager(mgr, opts)
+}
+
+// workerPredicates returns the predicates of the Worker controller. If the operation annotation is ignored, Workers
+// are reconciled when their generation or IgnitionSecretChangedAnnotation changes.
+func workerPredicates(ctx context.Context, mgr manager.Manager, ignoreOperationAnnotation bool) []predicate.Predicate {
+	if !ignoreOperationAnnotation {
+		return worker.DefaultPredicates(ctx, mgr, false)
+	}
+	return []predicate.Predicate{
+		extensionspredicate.ShootNotFailedPredicate(ctx, mgr),
+		predicate.Or(predicate.GenerationChangedPredicate{}, ignitionSecretChangedPredicate()),
+	}
 }
 
 // AddToManager adds a controller with the default Options.
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"sigs.k8s.io/yaml"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/butane"
+)
+
+// ignitionListKeys maps the paths of the Ignition lists whose entries are identified by a key to the name of that key.
+// Entries of these lists with the same key are merged into a single entry instead of being appended.
+var ignitionListKeys = map[string]string{
+	"storage.directories":   "path",
+	"storage.disks":         "device",
+	"storage.files":         "path",
+	"storage.filesystems":   "device",
+	"storage.links":         "path",
+	"storage.luks":          "name",
+	"storage.raid":          "name",
+	"systemd.units":         "name",
+	"systemd.units.dropins": "name",
+	"passwd.groups":         "name",
+	"passwd.users":          "name",
+}
+
+// decodeIgnition decodes the given extra ignition config of the given format. Butane configs are translated to Ignition.
+func decodeIgnition(data []byte, format metalv1alpha1.IgnitionFormat) (map[string]interface{}, error) {
+	if format == metalv1alpha1.IgnitionFormatButane {
+		ignition, _, err := butane.Translate(data)
+		return ignition, err
+	}
+
+	ignition := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &ignition); err != nil {
+		return nil, err
+	}
+	return ignition, nil
+}
+
+// mergeIgnition merges the Ignition config src into dst following the Ignition spec:
+//   - files, directories and links are merged by path, disks and filesystems by device,
+//     units, dropins, users, groups, raids and luks devices by name,
+//   - all other lists are appended, skipping entries which are already present,
+//   - objects are merged recursively.
+//
+// Entries of dst keep their position and precede new entries of src. Setting a field which is already set in dst
+// to a different value is a conflict and results in an error.
+func mergeIgnition(dst, src map[string]interface{}) error {
+	return mergeIgnitionMap(ignitionPath{}, dst, src)
+}
+
+// ignitionPath tracks the position in the Ignition config. schema is used to look up list keys, field is the
+// human-readable path (including the keys of list entries) used in errors.
+type ignitionPath struct {
+	schema string
+	field  string
+}
+
+func (p ignitionPath) child(key string) ignitionPath {
+	if p.schema == "" {
+		return ignitionPath{schema: key, field: key}
+	}
+	return ignitionPath{schema: p.schema + "." + key, field: p.field + "." + key}
+}
+
+func (p ignitionPath) entry(key, id string) ignitionPath {
+	return ignitionPath{schema: p.schema, field: fmt.Sprintf("%s[%s=%s]", p.field, key, id)}
+}
+
+func mergeIgnitionMap(path ignitionPath, dst, src map[string]interface{}) error {
+	for key, srcValue := range src {
+		dstValue, ok := dst[key]
+		if !ok || dstValue == nil {
+			dst[key] = srcValue
+			continue
+		}
+
+		merged, err := mergeIgnitionValue(path.child(key), dstValue, srcValue)
+		if err != nil {
+			return err
+		}
+		dst[key] = merged
+	}
+	return nil
+}
+
+func mergeIgnitionValue(path ignitionPath, dst, src interface{}) (interface{}, error) {
+	if src == nil {
+		return dst, nil
+	}
+
+	switch dstValue := dst.(type) {
+	case map[string]interface{}:
+		srcValue, ok := src.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("conflicting ignition field %s: cannot merge %T into an object", path.field, src)
+		}
+		if err := mergeIgnitionMap(path, dstValue, srcValue); err != nil {
+			return nil, err
+		}
+		return dstValue, nil
+
+	case []interface{}:
+		srcValue, ok := src.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("conflicting ignition field %s: cannot merge %T into a list", path.field, src)
+		}
+		if key, ok := ignitionListKeys[path.schema]; ok {
+			return mergeIgnitionKeyedList(path, key, dstValue, srcValue)
+		}
+		return mergeIgnitionList(dstValue, srcValue), nil
+
+	default:
+		if !reflect.DeepEqual(dst, src) {
+			return nil, fmt.Errorf("conflicting ignition field %s: %v is set to %v", path.field, dst, src)
+		}
+		return dst, nil
+	}
+}
+
+func mergeIgnitionKeyedList(path ignitionPath, key string, dst, src []interface{}) ([]interface{}, error) {
+	index := make(map[string]map[string]interface{}, len(dst))
+	for _, entry := range dst {
+		if entryMap, ok := entry.(map[string]interface{}); ok {
+			if id, ok := entryMap[key].(string); ok {
+				index[id] = entryMap
+			}
+		}
+	}
+
+	for _, entry := range src {
+		entryMap, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid ignition field %s: expected an object, got %T", path.field, entry)
+		}
+		id, ok := entryMap[key].(string)
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid ignition field %s: entry without %s", path.field, key)
+		}
+
+		existing, ok := index[id]
+		if !ok {
+			dst = append(dst, entryMap)
+			index[id] = entryMap
+			continue
+		}
+		if err := mergeIgnitionMap(path.entry(key, id), existing, entryMap); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+func mergeIgnitionList(dst, src []interface{}) []interface{} {
+	for _, entry := range src {
+		if !slices.ContainsFunc(dst, func(existing interface{}) bool { return reflect.DeepEqual(existing, entry) }) {
+			dst = append(dst, entry)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,206 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+const (
+	// IgnitionSecretControllerName is the name of the controller which triggers a Worker reconciliation
+	// whenever a referenced ignition secret changes.
+	IgnitionSecretControllerName = "metal-worker-ignition-secret"
+	// IgnitionSecretChangedAnnotation is the annotation of Workers which is set to the time of the last change of a
+	// referenced ignition secret if the operation annotation is ignored.
+	IgnitionSecretChangedAnnotation = "worker.metal.ironcore.dev/ignition-secret-changed"
+)
+
+// ignitionSecretReconciler triggers a reconciliation of Workers whenever one of the referenced secrets holding an
+// extra ignition snippet has been updated. Workers are annotated with the reconcile operation annotation, or with
+// IgnitionSecretChangedAnnotation if the Worker controller ignores the operation annotation.
+type ignitionSecretReconciler struct {
+	client                    client.Client
+	decoder                   runtime.Decoder
+	log                       logr.Logger
+	now                       func() time.Time
+	ignoreOperationAnnotation bool
+}
+
+func newIgnitionSecretReconciler(mgr manager.Manager, ignoreOperationAnnotation bool) *ignitionSecretReconciler {
+	return &ignitionSecretReconciler{
+		client:                    mgr.GetClient(),
+		decoder:                   serializer.NewCodecFactory(mgr.GetScheme(), serializer.EnableStrict).UniversalDecoder(),
+		log:                       mgr.GetLogger().WithName(IgnitionSecretControllerName),
+		now:                       time.Now,
+		ignoreOperationAnnotation: ignoreOperationAnnotation,
+	}
+}
+
+// ignitionSecretChangedPredicate only lets updates pass which change IgnitionSecretChangedAnnotation.
+func ignitionSecretChangedPredicate() predicate.Predicate {
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return e.ObjectOld != nil && e.ObjectNew != nil &&
+				e.ObjectOld.GetAnnotations()[IgnitionSecretChangedAnnotation] != e.ObjectNew.GetAnnotations()[IgnitionSecretChangedAnnotation]
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// addIgnitionSecretControllerToManager adds a controller watching the metadata of referenced secrets
+// (`ref-*`) in the shoot namespaces and enqueues the Workers using them as extra ignition.
+func addIgnitionSecretControllerToManager(mgr manager.Manager, opts AddOptions) error {
+	r := newIgnitionSecretReconciler(mgr, opts.IgnoreOperationAnnotation)
+
+	return builder.ControllerManagedBy(mgr).
+		Named(IgnitionSecretControllerName).
+		WithOptions(opts.Controller).
+		WatchesMetadata(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.mapSecretToWorkers),
+			builder.WithPredicates(referencedSecretUpdatePredicate()),
+		).
+		Complete(r)
+}
+
+// referencedSecretUpdatePredicate only lets updates of referenced resource secrets pass. Creations are
+// handled by the regular shoot reconciliation which deploys the referenced resources before the Worker.
+func referencedSecretUpdatePredicate() predicate.Predicate {
+	isReferencedSecret := func(obj client.Object) bool {
+		return obj != nil && strings.HasPrefix(obj.GetName(), v1beta1constants.ReferencedResourcesPrefix)
+	}
+
+	return predicate.Funcs{
+		CreateFunc: func(event.CreateEvent) bool { return false },
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return isReferencedSecret(e.ObjectNew) && e.ObjectOld.GetResourceVersion() != e.ObjectNew.GetResourceVersion()
+		},
+		DeleteFunc:  func(event.DeleteEvent) bool { return false },
+		GenericFunc: func(event.GenericEvent) bool { return false },
+	}
+}
+
+// mapSecretToWorkers maps a referenced secret to the metal Workers in the same namespace which use it
+// as extra ignition secret in at least one of their pools.
+func (r *ignitionSecretReconciler) mapSecretToWorkers(ctx context.Context, obj client.Object) []reconcile.Request {
+	log := r.log.WithValues("secret", client.ObjectKeyFromObject(obj))
+
+	workerList := &extensionsv1alpha1.WorkerList{}
+	if err := r.client.List(ctx, workerList, client.InNamespace(obj.GetNamespace())); err != nil {
+		log.Error(err, "Failed to list Workers")
+		return nil
+	}
+	if len(workerList.Items) == 0 {
+		return nil
+	}
+
+	cluster, err := extensionscontroller.GetCluster(ctx, r.client, obj.GetNamespace())
+	if err != nil {
+		log.Error(err, "Failed to get Cluster")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, worker := range workerList.Items {
+		if worker.Spec.Type != metal.Type {
+			continue
+		}
+
+		references, err := r.workerReferencesIgnitionSecret(&worker, cluster, obj.GetName())
+		if err != nil {
+			log.Error(err, "Failed to check ignition secret references of Worker", "worker", client.ObjectKeyFromObject(&worker))
+			continue
+		}
+		if references {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&worker)})
+		}
+	}
+
+	return requests
+}
+
+func (r *ignitionSecretReconciler) workerReferencesIgnitionSecret(worker *extensionsv1alpha1.Worker, cluster *extensionscontroller.Cluster, secretName string) (bool, error) {
+	for _, pool := range worker.Spec.Pools {
+		if pool.ProviderConfig == nil || pool.ProviderConfig.Raw == nil {
+			continue
+		}
+
+		workerConfig := &metalv1alpha1.WorkerConfig{}
+		if _, _, err := r.decoder.Decode(pool.ProviderConfig.Raw, nil, workerConfig); err != nil {
+			return false, fmt.Errorf("could not decode provider config of pool %s: %w", pool.Name, err)
+		}
+		if workerConfig.ExtraIgnition == nil {
+			continue
+		}
+
+		for _, secretRef := range ignitionSecretRefs(workerConfig.ExtraIgnition) {
+			name, err := lookupReferencedSecret(cluster, secretRef)
+			if err != nil {
+				return false, err
+			}
+			if name == secretName {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// Reconcile annotates the given Worker so that the Worker controller picks up the changed ignition secret.
+func (r *ignitionSecretReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	worker := &extensionsv1alpha1.Worker{}
+	if err := r.client.Get(ctx, req.NamespacedName, worker); err != nil {
+		if apierrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf("failed to get Worker %s: %w", req.NamespacedName, err)
+	}
+
+	if worker.DeletionTimestamp != nil {
+		return reconcile.Result{}, nil
+	}
+
+	patch := client.MergeFrom(worker.DeepCopy())
+	if r.ignoreOperationAnnotation {
+		metav1.SetMetaDataAnnotation(&worker.ObjectMeta, IgnitionSecretChangedAnnotation, r.now().UTC().Format(time.RFC3339Nano))
+	} else {
+		if worker.Annotations[v1beta1constants.GardenerOperation] == v1beta1constants.GardenerOperationReconcile {
+			return reconcile.Result{}, nil
+		}
+		metav1.SetMetaDataAnnotation(&worker.ObjectMeta, v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)
+	}
+
+	r.log.Info("Referenced ignition secret changed, triggering Worker reconciliation", "worker", req.NamespacedName)
+	if err := r.client.Patch(ctx, worker, patch); err != nil {
+		return reconcile.Result{}, fmt.Errorf("failed to annotate Worker %s: %w", req.NamespacedName, err)
+	}
+
+	return reconcile.Result{}, nil
+}
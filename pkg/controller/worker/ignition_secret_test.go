@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"encoding/json"
+	"time"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+var _ = Describe("Ignition secret", func() {
+	ns, _ := SetupTest()
+
+	var (
+		reconciler *ignitionSecretReconciler
+		secret     *corev1.Secret
+	)
+
+	BeforeEach(func(ctx SpecContext) {
+		shootJSON, err := json.Marshal(testCluster.Shoot)
+		Expect(err).NotTo(HaveOccurred())
+		cloudProfileJSON, err := json.Marshal(testCluster.CloudProfile)
+		Expect(err).NotTo(HaveOccurred())
+
+		cluster := &extensionsv1alpha1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ns.Name,
+			},
+			Spec: extensionsv1alpha1.ClusterSpec{
+				CloudProfile: runtime.RawExtension{Raw: cloudProfileJSON},
+				Seed:         runtime.RawExtension{Raw: []byte("{}")},
+				Shoot:        runtime.RawExtension{Raw: shootJSON},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cluster)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, cluster)
+
+		w.Spec.Type = metal.Type
+		Expect(k8sClient.Create(ctx, w)).To(Succeed())
+		DeferCleanup(k8sClient.Delete, w)
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      v1beta1constants.ReferencedResourcesPrefix + workerConfig.ExtraIgnition.SecretRef,
+			},
+		}
+
+		reconciler = &ignitionSecretReconciler{
+			client:  k8sClient,
+			decoder: serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder(),
+			log:     logf.Log,
+		}
+	})
+
+	It("should only pass updates of referenced secrets", func() {
+		pred := referencedSecretUpdatePredicate()
+		other := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "cloudprovider", ResourceVersion: "2"}}
+		oldSecret := secret.DeepCopy()
+		oldSecret.ResourceVersion = "1"
+		newSecret := secret.DeepCopy()
+		newSecret.ResourceVersion = "2"
+
+		Expect(pred.Create(event.CreateEvent{Object: newSecret})).To(BeFalse())
+		Expect(pred.Delete(event.DeleteEvent{Object: newSecret})).To(BeFalse())
+		Expect(pred.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: newSecret})).To(BeTrue())
+		Expect(pred.Update(event.UpdateEvent{ObjectOld: newSecret, ObjectNew: newSecret})).To(BeFalse())
+		Expect(pred.Update(event.UpdateEvent{ObjectOld: oldSecret, ObjectNew: other})).To(BeFalse())
+	})
+
+	It("should map a referenced ignition secret to the Workers using it", func(ctx SpecContext) {
+		Expect(reconciler.mapSecretToWorkers(ctx, secret)).To(ConsistOf(
+			reconcile.Request{NamespacedName: client.ObjectKeyFromObject(w)},
+		))
+
+		unrelated := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      v1beta1constants.ReferencedResourcesPrefix + "unrelated",
+			},
+		}
+		Expect(reconciler.mapSecretToWorkers(ctx, unrelated)).To(BeEmpty())
+	})
+
+	It("should annotate the Worker with the reconcile operation", func(ctx SpecContext) {
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(w)})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(Object(w)).Should(HaveField("ObjectMeta.Annotations",
+			HaveKeyWithValue(v1beta1constants.GardenerOperation, v1beta1constants.GardenerOperationReconcile)))
+	})
+
+	It("should annotate the Worker with the change time if the operation annotation is ignored", func(ctx SpecContext) {
+		reconciler.ignoreOperationAnnotation = true
+		reconciler.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+		_, err := reconciler.Reconcile(ctx, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(w)})
+		Expect(err).NotTo(HaveOccurred())
+
+		Eventually(Object(w)).Should(HaveField("ObjectMeta.Annotations", And(
+			HaveKeyWithValue(IgnitionSecretChangedAnnotation, "2025-01-01T00:00:00Z"),
+			Not(HaveKey(v1beta1constants.GardenerOperation)),
+		)))
+	})
+
+	It("should only pass Worker updates which change the ignition secret annotation", func() {
+		pred := ignitionSecretChangedPredicate()
+		oldWorker := w.DeepCopy()
+		newWorker := w.DeepCopy()
+		metav1.SetMetaDataAnnotation(&newWorker.ObjectMeta, IgnitionSecretChangedAnnotation, "2025-01-01T00:00:00Z")
+
+		Expect(pred.Update(event.UpdateEvent{ObjectOld: oldWorker, ObjectNew: newWorker})).To(BeTrue())
+		Expect(pred.Update(event.UpdateEvent{ObjectOld: newWorker, ObjectNew: newWorker})).To(BeFalse())
+		Expect(pred.Create(event.CreateEvent{Object: newWorker})).To(BeFalse())
+	})
+})
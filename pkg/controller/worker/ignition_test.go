@@ -0,0 +1,201 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/yaml"
+
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+)
+
+var _ = Describe("Ignition", func() {
+	toMap := func(data string) map[string]interface{} {
+		m := map[string]interface{}{}
+		Expect(yaml.Unmarshal([]byte(data), &m)).To(Succeed())
+		return m
+	}
+
+	Describe("#decodeIgnition", func() {
+		It("should decode an ignition config", func() {
+			Expect(decodeIgnition([]byte("ignition:\n  version: 3.3.0\n"), "")).To(Equal(toMap(`
+ignition:
+  version: 3.3.0
+`)))
+		})
+
+		It("should translate a butane config", func() {
+			Expect(decodeIgnition([]byte("variant: flatcar\nversion: 1.0.0\n"), apiv1alpha1.IgnitionFormatButane)).To(Equal(toMap(`
+ignition:
+  version: 3.3.0
+`)))
+		})
+
+		It("should return translation errors", func() {
+			_, err := decodeIgnition([]byte("variant: flatcar\nversion: 0.1.0\n"), apiv1alpha1.IgnitionFormatButane)
+			Expect(err).To(MatchError(ContainSubstring("unsupported butane version")))
+		})
+	})
+
+	Describe("#mergeIgnition", func() {
+		It("should merge files and units by their key", func() {
+			dst := toMap(`
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      source: data:,foo
+systemd:
+  units:
+  - name: foo.service
+    enabled: true
+    dropins:
+    - name: 10-foo.conf
+      contents: foo
+`)
+			src := toMap(`
+storage:
+  files:
+  - path: /etc/bar
+    contents:
+      source: data:,bar
+  - path: /etc/foo
+    mode: 420
+systemd:
+  units:
+  - name: foo.service
+    dropins:
+    - name: 20-bar.conf
+      contents: bar
+`)
+
+			Expect(mergeIgnition(dst, src)).To(Succeed())
+			Expect(dst).To(Equal(toMap(`
+storage:
+  files:
+  - path: /etc/foo
+    mode: 420
+    contents:
+      source: data:,foo
+  - path: /etc/bar
+    contents:
+      source: data:,bar
+systemd:
+  units:
+  - name: foo.service
+    enabled: true
+    dropins:
+    - name: 10-foo.conf
+      contents: foo
+    - name: 20-bar.conf
+      contents: bar
+`)))
+		})
+
+		It("should merge users by name and append unkeyed lists without duplicates", func() {
+			dst := toMap(`
+passwd:
+  users:
+  - name: core
+    sshAuthorizedKeys:
+    - key-a
+kernelArguments:
+  shouldExist:
+  - quiet
+`)
+			src := toMap(`
+passwd:
+  users:
+  - name: core
+    sshAuthorizedKeys:
+    - key-a
+    - key-b
+  - name: admin
+kernelArguments:
+  shouldExist:
+  - quiet
+  - nosmt
+`)
+
+			Expect(mergeIgnition(dst, src)).To(Succeed())
+			Expect(dst).To(Equal(toMap(`
+passwd:
+  users:
+  - name: core
+    sshAuthorizedKeys:
+    - key-a
+    - key-b
+  - name: admin
+kernelArguments:
+  shouldExist:
+  - quiet
+  - nosmt
+`)))
+		})
+
+		It("should accept identical entries", func() {
+			dst := toMap(`
+systemd:
+  units:
+  - name: foo.service
+    contents: foo
+`)
+			Expect(mergeIgnition(dst, toMap(`
+systemd:
+  units:
+  - name: foo.service
+    contents: foo
+`))).To(Succeed())
+			Expect(dst["systemd"]).To(HaveKeyWithValue("units", HaveLen(1)))
+		})
+
+		It("should return an error for conflicting files", func() {
+			dst := toMap(`
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      source: data:,foo
+`)
+			Expect(mergeIgnition(dst, toMap(`
+storage:
+  files:
+  - path: /etc/foo
+    contents:
+      source: data:,bar
+`))).To(MatchError(ContainSubstring("storage.files[path=/etc/foo].contents.source")))
+		})
+
+		It("should return an error for conflicting unit dropins", func() {
+			dst := toMap(`
+systemd:
+  units:
+  - name: foo.service
+    dropins:
+    - name: 10-foo.conf
+      contents: foo
+`)
+			Expect(mergeIgnition(dst, toMap(`
+systemd:
+  units:
+  - name: foo.service
+    dropins:
+    - name: 10-foo.conf
+      contents: bar
+`))).To(MatchError(ContainSubstring("systemd.units[name=foo.service].dropins[name=10-foo.conf].contents")))
+		})
+
+		It("should return an error for keyed entries without key", func() {
+			Expect(mergeIgnition(toMap(`
+systemd:
+  units: []
+`), toMap(`
+systemd:
+  units:
+  - enabled: true
+`))).To(MatchError(ContainSubstring("entry without name")))
+		})
+	})
+})
@@ -11,6 +11,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	metalhelper "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
 func (w *workerDelegate) GetMachineControllerManagerChartValues(ctx context.Context) (map[string]any, error) {
@@ -26,6 +27,13 @@ func (w *workerDelegate) GetMachineControllerManagerChartValues(ctx context.Cont
 	if ok && localAPI == "true" {
 		podLabels[metal.AllowEgressToIstioIngressLabel] = "allowed"
 	}
+	metalAPIEgress, err := metalhelper.FindMetalAPIEgress(w.cloudProfileConfig, w.cluster.Shoot.Spec.Region)
+	if err != nil {
+		return nil, err
+	}
+	if metalAPIEgress != nil {
+		podLabels[metal.AllowEgressToMetalAPILabel] = "allowed"
+	}
 
 	return map[string]any{
 		"providerName": metal.ProviderName,
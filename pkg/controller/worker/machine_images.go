@@ -6,6 +6,7 @@ package worker
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
@@ -21,6 +22,8 @@ import (
 // UpdateMachineImagesStatus updates the machine image status
 // with the used machine images for the `Worker` resource.
 func (w *workerDelegate) UpdateMachineImagesStatus(ctx context.Context) error {
+	defer observeReconcilePhase(reconcilePhaseUpdateMachineImagesStatus, time.Now())
+
 	var machineImages []apiv1alpha1.MachineImage
 	for _, pool := range w.worker.Spec.Pools {
 		arch := ptr.Deref[string](pool.Architecture, v1beta1constants.ArchitectureAMD64)
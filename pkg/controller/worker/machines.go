@@ -7,6 +7,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gardener/gardener/extensions/pkg/controller"
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
@@ -14,20 +18,25 @@ import (
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	"github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
-	"github.com/imdario/mergo"
+	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/yaml"
 
+	apishelper "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
 	metalv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
 // DeployMachineClasses generates and creates the metal specific machine classes.
 func (w *workerDelegate) DeployMachineClasses(ctx context.Context) error {
+	defer observeReconcilePhase(reconcilePhaseDeployMachineClasses, time.Now())
+
 	machineClasses, machineClassSecrets, err := w.generateMachineClassAndSecrets(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to generate machine classes and machine class secrets: %w", err)
@@ -44,23 +53,41 @@ func (w *workerDelegate) DeployMachineClasses(ctx context.Context) error {
 			return fmt.Errorf("failed to create/patch machineclass secret %s: %w", client.ObjectKeyFromObject(secret), err)
 		}
 	}
+	machineClassesGenerated.WithLabelValues(w.worker.Namespace).Set(float64(len(machineClasses)))
 
 	return nil
 }
 
 // GenerateMachineDeployments generates the configuration for the desired machine deployments.
 func (w *workerDelegate) GenerateMachineDeployments(ctx context.Context) (worker.MachineDeployments, error) {
+	defer observeReconcilePhase(reconcilePhaseGenerateMachineDeployments, time.Now())
+
 	var (
 		machineDeployments = worker.MachineDeployments{}
 	)
 
+	w.recordMachineTypesWithoutServerLabels()
+
 	for _, pool := range w.worker.Spec.Pools {
+		workerConfig, err := w.decodeWorkerConfig(pool)
+		if err != nil {
+			return nil, err
+		}
+
+		workerPoolHash, err := w.generateHashForWorkerPool(ctx, pool, workerConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		// Gardener configures the priority expander of the cluster-autoscaler with these priorities if priorities are
+		// configured for any worker pool of the Shoot.
+		priority := pool.Priority
+		if priority == nil {
+			priority = helper.FindMachineTypePriority(w.cloudProfileConfig, pool.MachineType)
+		}
+
 		zoneLen := int32(len(pool.Zones))
 		for zoneIndex := range pool.Zones {
-			workerPoolHash, err := w.generateHashForWorkerPool(pool)
-			if err != nil {
-				return nil, err
-			}
 			var (
 				deploymentName = fmt.Sprintf("%s-%s-z%d", w.worker.Namespace, pool.Name, zoneIndex+1)
 				className      = fmt.Sprintf("%s-%s", deploymentName, workerPoolHash)
@@ -86,10 +113,11 @@ func (w *workerDelegate) GenerateMachineDeployments(ctx context.Context) (worker
 				Minimum:              worker.DistributeOverZones(zoneIdx, pool.Minimum, zoneLen),
 				Maximum:              worker.DistributeOverZones(zoneIdx, pool.Maximum, zoneLen),
 				Strategy:             machineDeploymentStrategy,
-				Labels:               pool.Labels,
+				Labels:               getNodeLabelsForPool(pool),
 				Annotations:          pool.Annotations,
 				Taints:               pool.Taints,
 				MachineConfiguration: genericworkeractuator.ReadMachineConfiguration(pool),
+				Priority:             priority,
 			})
 		}
 	}
@@ -104,15 +132,12 @@ func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]
 	)
 
 	for _, pool := range w.worker.Spec.Pools {
-
-		workerConfig := &metalv1alpha1.WorkerConfig{}
-		if pool.ProviderConfig != nil && pool.ProviderConfig.Raw != nil {
-			if _, _, err := w.decoder.Decode(pool.ProviderConfig.Raw, nil, workerConfig); err != nil {
-				return nil, nil, fmt.Errorf("could not decode provider config: %+v", err)
-			}
+		workerConfig, err := w.decodeWorkerConfig(pool)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		workerPoolHash, err := w.generateHashForWorkerPool(pool)
+		workerPoolHash, err := w.generateHashForWorkerPool(ctx, pool, workerConfig)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to generate hash for worker pool %s: %w", pool.Name, err)
 		}
@@ -123,7 +148,7 @@ func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]
 			return nil, nil, err
 		}
 
-		serverLabels, err := w.getServerLabelsForMachine(pool.MachineType, workerConfig)
+		serverLabels, err := w.getServerLabelsForMachine(pool, workerConfig)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -133,23 +158,21 @@ func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]
 			metal.ServerLabelsFieldName: serverLabels,
 		}
 
-		if workerConfig.ExtraIgnition != nil {
-			if mergedIgnition, err := w.mergeIgnitionConfig(ctx, workerConfig); err != nil {
-				return nil, nil, err
-			} else if mergedIgnition != "" {
-				machineClassProviderSpec[metal.IgnitionFieldName] = mergedIgnition
-				machineClassProviderSpec[metal.IgnitionOverrideFieldName] = workerConfig.ExtraIgnition.Override
+		if pool.Volume != nil {
+			machineClassProviderSpec[metal.VolumeFieldName] = map[string]any{
+				metal.VolumeTypeFieldName: ptr.Deref(pool.Volume.Type, ""),
+				metal.VolumeSizeFieldName: pool.Volume.Size,
 			}
 		}
 
-		if workerConfig.Metadata != nil {
-			machineClassProviderSpec[metal.MetaDataFieldName] = workerConfig.Metadata
-		}
-
 		if workerConfig.IPAMConfig != nil {
 			machineClassProviderSpec[metal.IPAMConfigFieldName] = workerConfig.IPAMConfig
 		}
 
+		if workerConfig.NodeNamePolicy != "" {
+			machineClassProviderSpec[metal.NodeNamePolicyFieldName] = workerConfig.NodeNamePolicy
+		}
+
 		for zoneIndex, zone := range pool.Zones {
 			var (
 				deploymentName = fmt.Sprintf("%s-%s-z%d", w.worker.Namespace, pool.Name, zoneIndex+1)
@@ -159,22 +182,43 @@ func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]
 			// Here we are going to create the necessary objects:
 			// 1. construct a MachineClass per zone containing the ProviderSpec needed by the MCM
 			// 2. construct a Secret for each MachineClass containing the user-data
+			zoneProviderSpec := maps.Clone(machineClassProviderSpec)
+
+			minimum := worker.DistributeOverZones(int32(zoneIndex), pool.Minimum, int32(len(pool.Zones)))
+			nodeTemplate, err := w.getNodeTemplate(pool, zone, minimum)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			templateVariables, err := w.getTemplateVariables(pool, zone)
+			if err != nil {
+				return nil, nil, err
+			}
 
-			nodeTemplate := &machinecontrollerv1alpha1.NodeTemplate{}
-			if pool.NodeTemplate != nil {
-				nodeTemplate = &machinecontrollerv1alpha1.NodeTemplate{
-					Capacity:     pool.NodeTemplate.Capacity,
-					InstanceType: pool.MachineType,
-					Region:       w.worker.Spec.Region,
-					Zone:         zone,
+			if workerConfig.ExtraIgnition != nil {
+				if mergedIgnition, err := w.mergedIgnitionConfig(ctx, pool, zone, workerConfig, templateVariables); err != nil {
+					return nil, nil, err
+				} else if mergedIgnition != "" {
+					zoneProviderSpec[metal.IgnitionFieldName] = mergedIgnition
+					zoneProviderSpec[metal.IgnitionOverrideFieldName] = workerConfig.ExtraIgnition.Override
 				}
 			}
 
-			machineClassProviderSpec[metal.LabelsFieldName] = map[string]string{
+			if workerConfig.Metadata != nil {
+				metadata := workerConfig.Metadata
+				if workerConfig.RenderTemplates {
+					if metadata, err = helper.RenderTemplateMap(workerConfig.Metadata, templateVariables); err != nil {
+						return nil, nil, fmt.Errorf("failed to render metadata of worker pool %s: %w", pool.Name, err)
+					}
+				}
+				zoneProviderSpec[metal.MetaDataFieldName] = metadata
+			}
+
+			zoneProviderSpec[metal.LabelsFieldName] = map[string]string{
 				metal.ClusterNameLabel: w.cluster.ObjectMeta.Name,
 			}
 
-			machineClassProviderSpecJSON, err := json.Marshal(machineClassProviderSpec)
+			machineClassProviderSpecJSON, err := json.Marshal(zoneProviderSpec)
 			if err != nil {
 				return nil, nil, fmt.Errorf("failed to marshal machine class for machine pool %s: %w", pool.Name, err)
 			}
@@ -231,82 +275,251 @@ func (w *workerDelegate) generateMachineClassAndSecrets(ctx context.Context) ([]
 	return machineClasses, machineClassSecrets, nil
 }
 
-func (w *workerDelegate) generateHashForWorkerPool(pool v1alpha1.WorkerPool) (string, error) {
+func (w *workerDelegate) decodeWorkerConfig(pool v1alpha1.WorkerPool) (*metalv1alpha1.WorkerConfig, error) {
+	workerConfig := &metalv1alpha1.WorkerConfig{}
+	if pool.ProviderConfig != nil && pool.ProviderConfig.Raw != nil {
+		if _, _, err := w.decoder.Decode(pool.ProviderConfig.Raw, nil, workerConfig); err != nil {
+			return nil, fmt.Errorf("could not decode provider config: %+v", err)
+		}
+	}
+	return workerConfig, nil
+}
+
+func (w *workerDelegate) generateHashForWorkerPool(ctx context.Context, pool v1alpha1.WorkerPool, workerConfig *metalv1alpha1.WorkerConfig) (string, error) {
+	var additionalData []string
+	if workerConfig.RolloutOnConfigChange {
+		data, err := w.workerConfigHashData(ctx, pool, workerConfig)
+		if err != nil {
+			return "", err
+		}
+		additionalData = data
+	}
+
 	// Generate the worker pool hash.
-	return worker.WorkerPoolHash(pool, w.cluster, nil, nil, nil)
+	return worker.WorkerPoolHash(pool, w.cluster, additionalData, additionalData, additionalData)
 }
 
-func (w *workerDelegate) getServerLabelsForMachine(machineType string, workerConfig *metalv1alpha1.WorkerConfig) (map[string]string, error) {
+// workerConfigHashData returns the provider-specific data of the worker config which is part of the worker pool hash.
+// The content of the referenced ignition secret is included via the merged ignition of every zone, Metadata is left
+// out on purpose.
+func (w *workerDelegate) workerConfigHashData(ctx context.Context, pool v1alpha1.WorkerPool, workerConfig *metalv1alpha1.WorkerConfig) ([]string, error) {
+	var data []string
+
+	if workerConfig.ExtraIgnition != nil {
+		for _, zone := range pool.Zones {
+			templateVariables, err := w.getTemplateVariables(pool, zone)
+			if err != nil {
+				return nil, err
+			}
+			mergedIgnition, err := w.mergedIgnitionConfig(ctx, pool, zone, workerConfig, templateVariables)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, mergedIgnition)
+		}
+		data = append(data, strconv.FormatBool(workerConfig.ExtraIgnition.Override))
+	}
+
+	if len(workerConfig.ExtraServerLabels) > 0 {
+		serverLabels, err := json.Marshal(workerConfig.ExtraServerLabels)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, string(serverLabels))
+	}
+
+	if len(workerConfig.IPAMConfig) > 0 {
+		ipamConfig, err := json.Marshal(workerConfig.IPAMConfig)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, string(ipamConfig))
+	}
+
+	return data, nil
+}
+
+// getNodeLabelsForPool returns the labels of the nodes of the given pool. Nodes are labeled with the volume type
+// of the pool, so that node-local volumes are bound to the nodes providing the requested volume type.
+func getNodeLabelsForPool(pool v1alpha1.WorkerPool) map[string]string {
+	if pool.Volume == nil || pool.Volume.Type == nil {
+		return pool.Labels
+	}
+	labels := make(map[string]string, len(pool.Labels)+1)
+	maps.Copy(labels, pool.Labels)
+	labels[metal.VolumeTypeLabel] = *pool.Volume.Type
+	return labels
+}
+
+func (w *workerDelegate) getServerLabelsForMachine(pool v1alpha1.WorkerPool, workerConfig *metalv1alpha1.WorkerConfig) (map[string]string, error) {
 	combinedLabels := make(map[string]string)
 	for _, t := range w.cloudProfileConfig.MachineTypes {
-		if t.Name == machineType {
+		if t.Name == pool.MachineType {
 			for key, value := range t.ServerLabels {
 				combinedLabels[key] = value
 			}
 			break
 		}
 	}
+	if pool.Volume != nil && pool.Volume.Type != nil {
+		size, err := resource.ParseQuantity(pool.Volume.Size)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse volume size of worker pool %s: %w", pool.Name, err)
+		}
+		volume, err := helper.FindMachineTypeVolume(w.cloudProfileConfig, pool.MachineType, *pool.Volume.Type, size)
+		if err != nil {
+			return nil, err
+		}
+		if volume != nil {
+			for key, value := range volume.ServerLabels {
+				combinedLabels[key] = value
+			}
+		}
+	}
 	for key, value := range workerConfig.ExtraServerLabels {
 		combinedLabels[key] = value
 	}
 	if len(combinedLabels) == 0 {
-		return nil, fmt.Errorf("no server labels found for machine type %s or worker config", machineType)
+		return nil, fmt.Errorf("no server labels found for machine type %s or worker config", pool.MachineType)
 	}
 	return combinedLabels, nil
 }
 
-func (w *workerDelegate) mergeIgnitionConfig(ctx context.Context, workerConfig *metalv1alpha1.WorkerConfig) (string, error) {
-	rawIgnition := &map[string]interface{}{}
-
-	if workerConfig.ExtraIgnition.Raw != "" {
-		if err := yaml.Unmarshal([]byte(workerConfig.ExtraIgnition.Raw), rawIgnition); err != nil {
-			return "", err
+// recordMachineTypesWithoutServerLabels records the machine types of the worker pools which have no server labels in
+// the cloud profile. Such pools only get servers if their worker config selects them with extra server labels.
+func (w *workerDelegate) recordMachineTypesWithoutServerLabels() {
+	machineTypesWithoutServerLabels.DeletePartialMatch(prometheus.Labels{"namespace": w.worker.Namespace})
+	for _, pool := range w.worker.Spec.Pools {
+		if len(helper.FindMachineTypeServerLabels(w.cloudProfileConfig, pool.MachineType)) == 0 {
+			machineTypesWithoutServerLabels.WithLabelValues(w.worker.Namespace, pool.MachineType).Set(1)
 		}
 	}
+}
 
-	if workerConfig.ExtraIgnition.SecretRef != "" {
-		secretName, err := lookupReferencedSecret(w.cluster, workerConfig.ExtraIgnition.SecretRef)
-		if err != nil {
-			return "", err
-		}
-		secret := &corev1.Secret{}
-		secretKey := client.ObjectKey{Namespace: w.worker.Namespace, Name: secretName}
-		if err := w.client.Get(ctx, secretKey, secret); err != nil {
-			return "", fmt.Errorf("failed to get ignition secret %s: %w", workerConfig.ExtraIgnition.SecretRef, err)
-		}
+// getTemplateVariables returns the variables for rendering the templated fields of the worker config of the given
+// pool in the given zone.
+func (w *workerDelegate) getTemplateVariables(pool v1alpha1.WorkerPool, zone string) (*helper.TemplateVariables, error) {
+	variables := &helper.TemplateVariables{
+		TechnicalID: w.worker.Namespace,
+		PoolName:    pool.Name,
+		Zone:        zone,
+		Region:      w.worker.Spec.Region,
+		Networks:    map[string]string{},
+	}
 
-		secretContent, ok := secret.Data[metal.IgnitionFieldName]
-		if !ok {
-			return "", fmt.Errorf("ignition key not found in secret %s", workerConfig.ExtraIgnition.SecretRef)
+	if shoot := w.cluster.Shoot; shoot != nil {
+		variables.ShootName = shoot.Name
+		variables.ProjectName = strings.TrimSuffix(strings.TrimPrefix(w.worker.Namespace, v1beta1constants.TechnicalIDPrefix+"-"), "--"+shoot.Name)
+		if shoot.Spec.Networking != nil {
+			variables.NodeCIDR = ptr.Deref(shoot.Spec.Networking.Nodes, "")
 		}
+	}
 
-		ignitionSecret := map[string]interface{}{}
+	infrastructureConfig, err := apishelper.InfrastructureConfigFromCluster(w.cluster)
+	if err != nil {
+		return nil, err
+	}
+	for _, network := range infrastructureConfig.Networks {
+		variables.Networks[network.Name] = network.CIDR
+	}
 
-		if err := yaml.Unmarshal(secretContent, &ignitionSecret); err != nil {
-			return "", err
-		}
+	return variables, nil
+}
 
-		// append ignition
-		opt := mergo.WithAppendSlice
+// mergedIgnitionConfig returns the merged extra ignition of the given worker pool in the given zone. It is part of
+// both the worker pool hash and the machine class, and merged only once per reconciliation, so that the referenced
+// secrets are read and failures are counted only once.
+func (w *workerDelegate) mergedIgnitionConfig(ctx context.Context, pool v1alpha1.WorkerPool, zone string, workerConfig *metalv1alpha1.WorkerConfig, templateVariables *helper.TemplateVariables) (string, error) {
+	key := pool.Name + "/" + zone
+	if merged, ok := w.mergedIgnitions[key]; ok {
+		return merged.config, merged.err
+	}
+
+	config, err := w.mergeIgnitionConfig(ctx, workerConfig, templateVariables)
+	if err != nil {
+		ignitionMergeFailures.WithLabelValues(w.worker.Namespace).Inc()
+	}
+	if w.mergedIgnitions == nil {
+		w.mergedIgnitions = map[string]mergedIgnition{}
+	}
+	w.mergedIgnitions[key] = mergedIgnition{config: config, err: err}
+	return config, err
+}
+
+func (w *workerDelegate) mergeIgnitionConfig(ctx context.Context, workerConfig *metalv1alpha1.WorkerConfig, templateVariables *helper.TemplateVariables) (string, error) {
+	mergedIgnition := map[string]interface{}{}
+
+	if workerConfig.ExtraIgnition.Raw != "" {
+		raw := workerConfig.ExtraIgnition.Raw
+		if workerConfig.RenderTemplates {
+			rendered, err := helper.RenderTemplate(raw, templateVariables)
+			if err != nil {
+				return "", fmt.Errorf("failed to render raw ignition: %w", err)
+			}
+			raw = rendered
+		}
+		rawIgnition, err := decodeIgnition([]byte(raw), workerConfig.ExtraIgnition.Format)
+		if err != nil {
+			return "", fmt.Errorf("failed to decode raw ignition: %w", err)
+		}
+		mergedIgnition = rawIgnition
+	}
 
-		// merge both ignitions
-		err = mergo.Merge(rawIgnition, ignitionSecret, opt)
+	// merge the referenced secrets in order on top of the raw ignition
+	for _, secretRef := range ignitionSecretRefs(workerConfig.ExtraIgnition) {
+		ignitionSecret, err := w.getIgnitionSecretContent(ctx, secretRef, workerConfig.ExtraIgnition.Format)
 		if err != nil {
 			return "", err
 		}
+
+		if err := mergeIgnition(mergedIgnition, ignitionSecret); err != nil {
+			return "", fmt.Errorf("failed to merge ignition secret %s: %w", secretRef, err)
+		}
 	}
 
 	// avoid converting empty string to an empty map with non-zero length
-	if len(*rawIgnition) == 0 {
+	if len(mergedIgnition) == 0 {
 		return "", nil
 	}
 
-	mergedIgnition, err := yaml.Marshal(rawIgnition)
+	mergedIgnitionYAML, err := yaml.Marshal(mergedIgnition)
 	if err != nil {
 		return "", err
 	}
 
-	return string(mergedIgnition), nil
+	return string(mergedIgnitionYAML), nil
+}
+
+func (w *workerDelegate) getIgnitionSecretContent(ctx context.Context, secretRef string, format metalv1alpha1.IgnitionFormat) (map[string]interface{}, error) {
+	secretName, err := lookupReferencedSecret(w.cluster, secretRef)
+	if err != nil {
+		return nil, err
+	}
+	secret := &corev1.Secret{}
+	secretKey := client.ObjectKey{Namespace: w.worker.Namespace, Name: secretName}
+	if err := w.client.Get(ctx, secretKey, secret); err != nil {
+		return nil, fmt.Errorf("failed to get ignition secret %s: %w", secretRef, err)
+	}
+
+	secretContent, ok := secret.Data[metal.IgnitionFieldName]
+	if !ok {
+		return nil, fmt.Errorf("ignition key not found in secret %s", secretRef)
+	}
+
+	ignitionSecret, err := decodeIgnition(secretContent, format)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode ignition secret %s: %w", secretRef, err)
+	}
+	return ignitionSecret, nil
+}
+
+// ignitionSecretRefs returns the references to the ignition secrets in the order they are merged.
+func ignitionSecretRefs(ignitionConfig *metalv1alpha1.IgnitionConfig) []string {
+	var secretRefs []string
+	if ignitionConfig.SecretRef != "" {
+		secretRefs = append(secretRefs, ignitionConfig.SecretRef)
+	}
+	return append(secretRefs, ignitionConfig.SecretRefs...)
 }
 
 func lookupReferencedSecret(cluster *controller.Cluster, refname string) (string, error) {
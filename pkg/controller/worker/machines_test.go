@@ -10,9 +10,11 @@ import (
 	"github.com/gardener/gardener/extensions/pkg/controller/worker"
 	genericworkeractuator "github.com/gardener/gardener/extensions/pkg/controller/worker/genericactuator"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	gardenerextensionv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -20,6 +22,7 @@ import (
 	"k8s.io/utils/ptr"
 	. "sigs.k8s.io/controller-runtime/pkg/envtest/komega"
 
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
@@ -65,7 +68,7 @@ var _ = Describe("Machines", func() {
 			}
 			By("deploying the machine class for a given multi zone cluster")
 			decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
-			workerDelegate, err = NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster)
+			workerDelegate, err = NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), w, testCluster)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -76,6 +79,7 @@ var _ = Describe("Machines", func() {
 
 		It("should create the expected machine class for a multi zone cluster", func(ctx SpecContext) {
 			Expect(workerDelegate.DeployMachineClasses(ctx)).To(Succeed())
+			Expect(testutil.ToFloat64(machineClassesGenerated.WithLabelValues(ns.Name))).To(Equal(2.0))
 			By("ensuring that the machine class for each pool has been deployed")
 			machineClassProviderSpec := map[string]any{
 				"image": "registry/my-os",
@@ -85,6 +89,11 @@ var _ = Describe("Machines", func() {
 				metal.ServerLabelsFieldName: map[string]string{
 					"foo":  "bar",
 					"foo1": "bar1",
+					"disk": "fast-20gi",
+				},
+				metal.VolumeFieldName: map[string]any{
+					metal.VolumeTypeFieldName: "fast",
+					metal.VolumeSizeFieldName: "10Gi",
 				},
 				metal.IgnitionFieldName:         yamlString,
 				metal.IgnitionOverrideFieldName: true,
@@ -135,7 +144,7 @@ var _ = Describe("Machines", func() {
 			className2      = fmt.Sprintf("%s-%s", deploymentName2, workerPoolHash)
 		)
 		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
-		workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), "", w, testCluster)
+		workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), w, testCluster)
 		Expect(err).NotTo(HaveOccurred())
 
 		By("generating the machine deployments")
@@ -158,7 +167,7 @@ var _ = Describe("Machines", func() {
 						},
 					},
 				},
-				Labels:               pool.Labels,
+				Labels:               map[string]string{"foo": "bar", metal.VolumeTypeLabel: "fast"},
 				Annotations:          pool.Annotations,
 				Taints:               pool.Taints,
 				MachineConfiguration: genericworkeractuator.ReadMachineConfiguration(pool),
@@ -178,13 +187,160 @@ var _ = Describe("Machines", func() {
 						},
 					},
 				},
-				Labels:               pool.Labels,
+				Labels:               map[string]string{"foo": "bar", metal.VolumeTypeLabel: "fast"},
 				Annotations:          pool.Annotations,
 				Taints:               pool.Taints,
 				MachineConfiguration: genericworkeractuator.ReadMachineConfiguration(pool),
 			},
 		}))
 	})
+
+	It("should record the machine types without server labels", func() {
+		poolWithoutServerLabels := *pool.DeepCopy()
+		poolWithoutServerLabels.Name = "pool-without-server-labels"
+		poolWithoutServerLabels.MachineType = "unknown"
+		workerWithoutServerLabels := w.DeepCopy()
+		workerWithoutServerLabels.Namespace = "shoot--foo--without-server-labels"
+		workerWithoutServerLabels.Spec.Pools = []gardenerextensionv1alpha1.WorkerPool{pool, poolWithoutServerLabels}
+
+		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
+		delegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), workerWithoutServerLabels, testCluster)
+		Expect(err).NotTo(HaveOccurred())
+		delegate.(*workerDelegate).recordMachineTypesWithoutServerLabels()
+
+		Expect(testutil.ToFloat64(machineTypesWithoutServerLabels.WithLabelValues(workerWithoutServerLabels.Namespace, "unknown"))).To(Equal(1.0))
+		Expect(testutil.CollectAndCount(machineTypesWithoutServerLabels)).To(Equal(1))
+
+		By("deleting the metrics of the shoot")
+		deleteShootMetrics(workerWithoutServerLabels.Namespace)
+		Expect(testutil.CollectAndCount(machineTypesWithoutServerLabels)).To(BeZero())
+	})
+
+	It("should only roll the worker pool on config changes if opted in", func(ctx SpecContext) {
+		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
+		generateClassName := func() string {
+			GinkgoHelper()
+			workerDelegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), w, testCluster)
+			Expect(err).NotTo(HaveOccurred())
+			machineDeployments, err := workerDelegate.GenerateMachineDeployments(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			return machineDeployments[0].ClassName
+		}
+		cfg := workerConfig.DeepCopy()
+		updateWorkerConfig := func(mutate func()) {
+			GinkgoHelper()
+			mutate()
+			raw, err := json.Marshal(cfg)
+			Expect(err).NotTo(HaveOccurred())
+			w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{Raw: raw}
+		}
+		ignitionSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns.Name,
+				Name:      v1beta1constants.ReferencedResourcesPrefix + workerConfig.ExtraIgnition.SecretRef,
+			},
+		}
+		updateIgnitionSecret := func(content string) {
+			GinkgoHelper()
+			Eventually(Update(ignitionSecret, func() {
+				ignitionSecret.Data["ignition"] = []byte(content)
+			})).Should(Succeed())
+		}
+
+		hashWithoutAdditionalData := func() string {
+			GinkgoHelper()
+			workerPoolHash, err := worker.WorkerPoolHash(w.Spec.Pools[0], testCluster, nil, nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			return fmt.Sprintf("%s-%s-z1-%s", w.Namespace, w.Spec.Pools[0].Name, workerPoolHash)
+		}
+
+		updateWorkerConfig(func() {})
+		className := generateClassName()
+		Expect(className).To(Equal(hashWithoutAdditionalData()))
+
+		By("changing the ignition secret without opting in")
+		updateIgnitionSecret("a:\n  c: baz\n")
+		Expect(generateClassName()).To(Equal(className))
+
+		By("changing the extra server labels without opting in")
+		updateWorkerConfig(func() { cfg.ExtraServerLabels = map[string]string{"foo1": "baz"} })
+		Expect(generateClassName()).NotTo(Equal(className))
+		className = generateClassName()
+
+		By("opting in")
+		updateWorkerConfig(func() { cfg.RolloutOnConfigChange = true })
+		Expect(generateClassName()).NotTo(Equal(className))
+		className = generateClassName()
+
+		By("changing the ignition secret")
+		updateIgnitionSecret("a:\n  c: qux\n")
+		Expect(generateClassName()).NotTo(Equal(className))
+		className = generateClassName()
+
+		By("keeping the hash if nothing changed")
+		Expect(generateClassName()).To(Equal(className))
+
+		By("keeping the hash of pools which did not opt in")
+		updateWorkerConfig(func() { cfg.RolloutOnConfigChange = false })
+		Expect(generateClassName()).To(Equal(hashWithoutAdditionalData()))
+	})
+
+	It("should count a failing ignition merge once per reconciliation", func(ctx SpecContext) {
+		cfg := workerConfig.DeepCopy()
+		cfg.RolloutOnConfigChange = true
+		cfg.RenderTemplates = true
+		cfg.ExtraIgnition = &apiv1alpha1.IgnitionConfig{
+			Raw: "unknown: {{ .Unknown }}\n",
+		}
+		raw, err := json.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{Raw: raw}
+		failures := ignitionMergeFailures.WithLabelValues(w.Namespace)
+		count := testutil.ToFloat64(failures)
+
+		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
+		delegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), w, testCluster)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(delegate.DeployMachineClasses(ctx)).To(MatchError(ContainSubstring("failed to render raw ignition")))
+		_, err = delegate.GenerateMachineDeployments(ctx)
+		Expect(err).To(MatchError(ContainSubstring("failed to render raw ignition")))
+
+		Expect(testutil.ToFloat64(failures) - count).To(Equal(1.0))
+	})
+
+	It("should render the templated metadata and ignition per zone", func(ctx SpecContext) {
+		cfg := workerConfig.DeepCopy()
+		cfg.RenderTemplates = true
+		cfg.ExtraIgnition = &apiv1alpha1.IgnitionConfig{
+			Raw: "zone: {{ .Zone }}\npool: {{ .PoolName }}\n",
+		}
+		cfg.Metadata = map[string]string{
+			"zone":   "{{ .Zone }}",
+			"region": "{{ .Region }}",
+			"shoot":  "{{ .ShootName }}",
+		}
+		raw, err := json.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		w.Spec.Pools[0].ProviderConfig = &runtime.RawExtension{Raw: raw}
+
+		decoder := serializer.NewCodecFactory(k8sClient.Scheme(), serializer.EnableStrict).UniversalDecoder()
+		delegate, err := NewWorkerDelegate(k8sClient, decoder, k8sClient.Scheme(), w, testCluster)
+		Expect(err).NotTo(HaveOccurred())
+		machineClasses, _, err := delegate.(*workerDelegate).generateMachineClassAndSecrets(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machineClasses).To(HaveLen(2))
+
+		for i, zone := range pool.Zones {
+			providerSpec := map[string]any{}
+			Expect(json.Unmarshal(machineClasses[i].ProviderSpec.Raw, &providerSpec)).To(Succeed())
+			Expect(providerSpec).To(HaveKeyWithValue(metal.IgnitionFieldName, fmt.Sprintf("pool: %s\nzone: %s\n", pool.Name, zone)))
+			Expect(providerSpec).To(HaveKeyWithValue(metal.MetaDataFieldName, map[string]any{
+				"zone":   zone,
+				"region": w.Spec.Region,
+				"shoot":  testCluster.Shoot.Name,
+			}))
+		}
+	})
 })
 
 func encodeMap(m map[string]any) []byte {
@@ -0,0 +1,67 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+)
+
+const (
+	reconcilePhaseTotal                      = "total"
+	reconcilePhaseGenerateMachineDeployments = "generate_machine_deployments"
+	reconcilePhaseDeployMachineClasses       = "deploy_machine_classes"
+	reconcilePhaseUpdateMachineImagesStatus  = "update_machine_images_status"
+)
+
+var (
+	machineClassesGenerated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metal.MetricsNamespace,
+		Subsystem: "worker",
+		Name:      "machine_classes",
+		Help:      "Number of MachineClasses deployed by the last successful worker reconciliation, partitioned by shoot namespace.",
+	}, []string{"namespace"})
+	ignitionMergeFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metal.MetricsNamespace,
+		Subsystem: "worker",
+		Name:      "ignition_merge_failures_total",
+		Help:      "Number of worker reconciliations which failed to render or merge the extra ignition of a worker pool, partitioned by shoot namespace.",
+	}, []string{"namespace"})
+	machineTypesWithoutServerLabels = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: metal.MetricsNamespace,
+		Subsystem: "worker",
+		Name:      "machine_types_without_server_labels",
+		Help:      "Machine types used by worker pools which have no server labels in the cloud profile, partitioned by shoot namespace and machine type.",
+	}, []string{"namespace", "machine_type"})
+	reconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metal.MetricsNamespace,
+		Subsystem: "worker",
+		Name:      "reconcile_phase_duration_seconds",
+		Help:      "Duration of the phases of worker reconciliations. The phase total covers the whole reconciliation.",
+		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 15),
+	}, []string{"phase"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(machineClassesGenerated, ignitionMergeFailures, machineTypesWithoutServerLabels, reconcilePhaseDuration)
+}
+
+// observeReconcilePhase records the duration of the given reconcile phase which started at the given time. It is
+// meant to be deferred at the beginning of the phase.
+func observeReconcilePhase(phase string, start time.Time) {
+	reconcilePhaseDuration.WithLabelValues(phase).Observe(time.Since(start).Seconds())
+}
+
+// deleteShootMetrics deletes the series of the shoot in the given namespace, e.g. after its worker was deleted.
+func deleteShootMetrics(namespace string) {
+	labels := prometheus.Labels{"namespace": namespace}
+	machineClassesGenerated.DeletePartialMatch(labels)
+	ignitionMergeFailures.DeletePartialMatch(labels)
+	machineTypesWithoutServerLabels.DeletePartialMatch(labels)
+	metal.DeleteAPIRequestMetrics(namespace)
+}
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"fmt"
+
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	"github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
+)
+
+// resourceGPU is the name of the GPU resource in node templates, which the cluster-autoscaler maps to the GPU
+// resource of the nodes.
+const resourceGPU corev1.ResourceName = "gpu"
+
+// getNodeTemplate returns the node template of the machine class of the given pool in the given zone. A node template
+// configured in the pool takes precedence. Otherwise, the node template is derived from the machine type if the
+// machine deployment of the zone may be scaled to zero, so that the cluster-autoscaler can scale it up from zero. The
+// servers of the metal API are not sampled, as the credentials of the Shoot cannot read the server inventory.
+func (w *workerDelegate) getNodeTemplate(pool v1alpha1.WorkerPool, zone string, minimum int32) (*machinecontrollerv1alpha1.NodeTemplate, error) {
+	if pool.NodeTemplate != nil {
+		return &machinecontrollerv1alpha1.NodeTemplate{
+			Capacity:     pool.NodeTemplate.Capacity,
+			InstanceType: pool.MachineType,
+			Region:       w.worker.Spec.Region,
+			Zone:         zone,
+		}, nil
+	}
+	if minimum > 0 {
+		return &machinecontrollerv1alpha1.NodeTemplate{}, nil
+	}
+
+	capacity, err := w.machineTypeCapacity(pool.MachineType)
+	if err != nil {
+		return nil, err
+	}
+
+	return &machinecontrollerv1alpha1.NodeTemplate{
+		Capacity:     capacity,
+		InstanceType: pool.MachineType,
+		Region:       w.worker.Spec.Region,
+		Zone:         zone,
+		Architecture: ptr.To(ptr.Deref(pool.Architecture, v1beta1constants.ArchitectureAMD64)),
+	}, nil
+}
+
+// machineTypeCapacity returns the resources of the servers of the given machine type. The CPU, GPU and memory are
+// taken from the machine type of the CloudProfile. The capacity of the provider specific machine type, which describes
+// the server inventory more precisely, takes precedence over it and adds the preallocated huge pages.
+func (w *workerDelegate) machineTypeCapacity(machineTypeName string) (corev1.ResourceList, error) {
+	capacity := corev1.ResourceList{}
+
+	if cloudProfile := w.cluster.CloudProfile; cloudProfile != nil {
+		for _, machineType := range cloudProfile.Spec.MachineTypes {
+			if machineType.Name == machineTypeName {
+				capacity[corev1.ResourceCPU] = machineType.CPU
+				capacity[corev1.ResourceMemory] = machineType.Memory
+				capacity[resourceGPU] = machineType.GPU
+				break
+			}
+		}
+	}
+
+	if machineTypeCapacity := helper.FindMachineTypeCapacity(w.cloudProfileConfig, machineTypeName); machineTypeCapacity != nil {
+		capacity[corev1.ResourceCPU] = machineTypeCapacity.CPU
+		capacity[corev1.ResourceMemory] = machineTypeCapacity.Memory
+		for size, hugePages := range machineTypeCapacity.HugePages {
+			capacity[corev1.ResourceName(corev1.ResourceHugePagesPrefix+size)] = hugePages
+		}
+	}
+
+	if len(capacity) == 0 {
+		return nil, fmt.Errorf("machine type %s not found in cloud profile", machineTypeName)
+	}
+	return capacity, nil
+}
@@ -0,0 +1,132 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package worker
+
+import (
+	"encoding/json"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	gardenerextensionv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	machinecontrollerv1alpha1 "github.com/gardener/machine-controller-manager/pkg/apis/machine/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	apiv1alpha1 "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/v1alpha1"
+)
+
+var _ = Describe("NodeTemplate", func() {
+	var (
+		cluster            *extensionscontroller.Cluster
+		cloudProfileConfig *apiv1alpha1.CloudProfileConfig
+		worker             *gardenerextensionv1alpha1.Worker
+		workerPool         gardenerextensionv1alpha1.WorkerPool
+	)
+
+	BeforeEach(func() {
+		cloudProfileConfig = &apiv1alpha1.CloudProfileConfig{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiv1alpha1.SchemeGroupVersion.String(),
+				Kind:       "CloudProfileConfig",
+			},
+			MachineTypes: []apiv1alpha1.MachineType{{
+				Name:         "large",
+				ServerLabels: map[string]string{"foo": "bar"},
+			}},
+		}
+		cluster = &extensionscontroller.Cluster{
+			CloudProfile: &gardencorev1beta1.CloudProfile{
+				Spec: gardencorev1beta1.CloudProfileSpec{
+					MachineTypes: []gardencorev1beta1.MachineType{{
+						Name:   "large",
+						CPU:    resource.MustParse("64"),
+						GPU:    resource.MustParse("2"),
+						Memory: resource.MustParse("256Gi"),
+					}},
+				},
+			},
+		}
+		worker = &gardenerextensionv1alpha1.Worker{
+			Spec: gardenerextensionv1alpha1.WorkerSpec{
+				Region: "region",
+			},
+		}
+		workerPool = gardenerextensionv1alpha1.WorkerPool{
+			Name:         "pool",
+			MachineType:  "large",
+			Architecture: ptr.To("arm64"),
+			Zones:        []string{"zone1"},
+		}
+	})
+
+	newWorkerDelegate := func() *workerDelegate {
+		cloudProfileConfigJSON, err := json.Marshal(cloudProfileConfig)
+		Expect(err).NotTo(HaveOccurred())
+		cluster.CloudProfile.Spec.ProviderConfig = &apiruntime.RawExtension{Raw: cloudProfileConfigJSON}
+
+		delegate, err := NewWorkerDelegate(nil, nil, nil, worker, cluster)
+		Expect(err).NotTo(HaveOccurred())
+		return delegate.(*workerDelegate)
+	}
+
+	It("should use the node template of the pool", func() {
+		workerPool.NodeTemplate = &gardenerextensionv1alpha1.NodeTemplate{
+			Capacity: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+		}
+
+		Expect(newWorkerDelegate().getNodeTemplate(workerPool, "zone1", 0)).To(Equal(&machinecontrollerv1alpha1.NodeTemplate{
+			Capacity:     corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+			InstanceType: "large",
+			Region:       "region",
+			Zone:         "zone1",
+		}))
+	})
+
+	It("should not derive a node template if the machine deployment cannot be scaled to zero", func() {
+		Expect(newWorkerDelegate().getNodeTemplate(workerPool, "zone1", 1)).To(Equal(&machinecontrollerv1alpha1.NodeTemplate{}))
+	})
+
+	It("should derive the node template from the machine type of the cloud profile", func() {
+		Expect(newWorkerDelegate().getNodeTemplate(workerPool, "zone1", 0)).To(Equal(&machinecontrollerv1alpha1.NodeTemplate{
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("64"),
+				corev1.ResourceMemory: resource.MustParse("256Gi"),
+				resourceGPU:           resource.MustParse("2"),
+			},
+			InstanceType: "large",
+			Region:       "region",
+			Zone:         "zone1",
+			Architecture: ptr.To("arm64"),
+		}))
+	})
+
+	It("should prefer the capacity of the provider specific machine type", func() {
+		cloudProfileConfig.MachineTypes[0].Capacity = &apiv1alpha1.MachineTypeCapacity{
+			CPU:       resource.MustParse("96"),
+			Memory:    resource.MustParse("512Gi"),
+			HugePages: map[string]resource.Quantity{"1Gi": resource.MustParse("64Gi")},
+		}
+
+		nodeTemplate, err := newWorkerDelegate().getNodeTemplate(workerPool, "zone1", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodeTemplate.Capacity).To(Equal(corev1.ResourceList{
+			corev1.ResourceCPU:                   resource.MustParse("96"),
+			corev1.ResourceMemory:                resource.MustParse("512Gi"),
+			resourceGPU:                          resource.MustParse("2"),
+			corev1.ResourceName("hugepages-1Gi"): resource.MustParse("64Gi"),
+		}))
+	})
+
+	It("should return an error if the machine type is unknown", func() {
+		workerPool.MachineType = "unknown"
+
+		_, err := newWorkerDelegate().getNodeTemplate(workerPool, "zone1", 0)
+		Expect(err).To(HaveOccurred())
+	})
+})
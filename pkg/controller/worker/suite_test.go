@@ -94,6 +94,7 @@ var _ = BeforeSuite(func() {
 			modutils.Dir("github.com/gardener/machine-controller-manager", "kubernetes", "crds", "machine.sapcloud.io_machinedeployments.yaml"),
 			modutils.Dir("github.com/gardener/machine-controller-manager", "kubernetes", "crds", "machine.sapcloud.io_machines.yaml"),
 			modutils.Dir("github.com/gardener/machine-controller-manager", "kubernetes", "crds", "machine.sapcloud.io_machinesets.yaml"),
+			filepath.Join("..", "..", "..", "example", "20-crd-extensions.gardener.cloud_clusters.yaml"),
 			filepath.Join("..", "..", "..", "example", "20-crd-extensions.gardener.cloud_controlplanes.yaml"),
 			filepath.Join("..", "..", "..", "example", "20-crd-extensions.gardener.cloud_workers.yaml"),
 		},
@@ -250,6 +251,23 @@ func SetupTest() (*corev1.Namespace, *gardener.ChartApplier) {
 					ServerLabels: map[string]string{
 						"foo": "bar",
 					},
+					Volumes: []apiv1alpha1.MachineTypeVolume{
+						{
+							Type:         "fast",
+							Size:         resource.MustParse("1Ti"),
+							ServerLabels: map[string]string{"disk": "fast-1ti"},
+						},
+						{
+							Type:         "fast",
+							Size:         resource.MustParse("20Gi"),
+							ServerLabels: map[string]string{"disk": "fast-20gi"},
+						},
+						{
+							Type:         "slow",
+							Size:         resource.MustParse("10Gi"),
+							ServerLabels: map[string]string{"disk": "slow-10gi"},
+						},
+					},
 				},
 			},
 			MachineImages: []apiv1alpha1.MachineImages{
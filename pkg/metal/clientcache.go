@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	// Register the oidc auth provider for cloudprovider secrets with OIDC credentials.
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// MetricsNamespace is the namespace of the Prometheus metrics of the extension.
+	MetricsNamespace = "provider_ironcore_metal"
+
+	// clientCacheIdleTimeout is the duration after which unused clients are evicted from the client cache, e.g. the
+	// clients of deleted secrets.
+	clientCacheIdleTimeout = time.Hour
+)
+
+var (
+	clientCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Subsystem: "client_cache",
+		Name:      "requests_total",
+		Help:      "Number of metal API client requests to the client cache, partitioned by result (hit or miss).",
+	}, []string{"result"})
+	clientCacheEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Subsystem: "client_cache",
+		Name:      "evictions_total",
+		Help:      "Number of metal API clients evicted from the client cache, partitioned by reason (changed or idle).",
+	}, []string{"reason"})
+	clientCacheEntries = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: MetricsNamespace,
+		Subsystem: "client_cache",
+		Name:      "entries",
+		Help:      "Number of metal API clients in the client cache.",
+	})
+
+	metalClientCache = newClientCache(newMetalClient)
+)
+
+func init() {
+	metrics.Registry.MustRegister(clientCacheRequests, clientCacheEvictions, clientCacheEntries)
+}
+
+// clientCache caches metal API clients per secret. A cached client is reused as long as the UID and resourceVersion
+// of the secret it was created from do not change, so that its HTTP transport and REST mapper are shared by all
+// requests for the same credentials.
+type clientCache struct {
+	mu      sync.Mutex
+	entries map[types.UID]*clientCacheEntry
+
+	now       func() time.Time
+	newClient func(namespace string, kubeconfig []byte) (client.Client, error)
+}
+
+type clientCacheEntry struct {
+	resourceVersion string
+	client          client.Client
+	lastUsed        time.Time
+}
+
+func newClientCache(newClient func(namespace string, kubeconfig []byte) (client.Client, error)) *clientCache {
+	return &clientCache{
+		entries:   map[types.UID]*clientCacheEntry{},
+		now:       time.Now,
+		newClient: newClient,
+	}
+}
+
+// get returns the cached client for the given secret or creates a new one from the given kubeconfig. Secrets without
+// UID are not cached.
+func (c *clientCache) get(secret *corev1.Secret, kubeconfig []byte) (client.Client, error) {
+	if secret.UID == "" {
+		clientCacheRequests.WithLabelValues("miss").Inc()
+		return c.newClient(secret.Namespace, kubeconfig)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if entry, ok := c.entries[secret.UID]; ok {
+		if entry.resourceVersion == secret.ResourceVersion {
+			clientCacheRequests.WithLabelValues("hit").Inc()
+			entry.lastUsed = now
+			return entry.client, nil
+		}
+		delete(c.entries, secret.UID)
+		clientCacheEvictions.WithLabelValues("changed").Inc()
+	}
+	clientCacheRequests.WithLabelValues("miss").Inc()
+	c.evictIdle(now)
+	defer func() { clientCacheEntries.Set(float64(len(c.entries))) }()
+
+	metalClient, err := c.newClient(secret.Namespace, kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	c.entries[secret.UID] = &clientCacheEntry{
+		resourceVersion: secret.ResourceVersion,
+		client:          metalClient,
+		lastUsed:        now,
+	}
+	return metalClient, nil
+}
+
+func (c *clientCache) evictIdle(now time.Time) {
+	for uid, entry := range c.entries {
+		if now.Sub(entry.lastUsed) > clientCacheIdleTimeout {
+			delete(c.entries, uid)
+			clientCacheEvictions.WithLabelValues("idle").Inc()
+		}
+	}
+}
+
+// newMetalClient creates a metal API client from the given kubeconfig of the shoot in the given namespace.
+func newMetalClient(namespace string, kubeconfig []byte) (client.Client, error) {
+	clientCfgs, err := restConfigsFromKubeconfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rest config from secret: %w", err)
+	}
+	clientCfg := clientCfgs[0]
+	// The HTTP client is shared by the client and its REST mapper.
+	var httpClient *http.Client
+	if len(clientCfgs) > 1 {
+		httpClient, err = newFailoverHTTPClient(clientCfgs)
+	} else {
+		httpClient, err = rest.HTTPClientFor(clientCfg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create http client from secret: %w", err)
+	}
+	httpClient.Transport = &instrumentedTransport{namespace: namespace, transport: httpClient.Transport}
+	c, err := client.New(clientCfg, client.Options{Scheme: metalScheme, HTTPClient: httpClient})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client from secret: %w", err)
+	}
+	return c, nil
+}
@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("ClientCache", func() {
+	var (
+		cache      *clientCache
+		now        time.Time
+		newClients int
+		secret     *corev1.Secret
+
+		hits, misses, changed, idle float64
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		newClients = 0
+		cache = newClientCache(func(_ string, kubeconfig []byte) (client.Client, error) {
+			if string(kubeconfig) == "invalid" {
+				return nil, fmt.Errorf("invalid kubeconfig")
+			}
+			newClients++
+			return fakeclient.NewClientBuilder().Build(), nil
+		})
+		cache.now = func() time.Time { return now }
+
+		secret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "uid-1", ResourceVersion: "1"}}
+
+		hits = testutil.ToFloat64(clientCacheRequests.WithLabelValues("hit"))
+		misses = testutil.ToFloat64(clientCacheRequests.WithLabelValues("miss"))
+		changed = testutil.ToFloat64(clientCacheEvictions.WithLabelValues("changed"))
+		idle = testutil.ToFloat64(clientCacheEvictions.WithLabelValues("idle"))
+	})
+
+	It("should reuse the client as long as the secret does not change", func() {
+		c1, err := cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+		c2, err := cache.get(secret.DeepCopy(), []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c2).To(BeIdenticalTo(c1))
+		Expect(newClients).To(Equal(1))
+		Expect(testutil.ToFloat64(clientCacheRequests.WithLabelValues("hit")) - hits).To(Equal(1.0))
+		Expect(testutil.ToFloat64(clientCacheRequests.WithLabelValues("miss")) - misses).To(Equal(1.0))
+		Expect(testutil.ToFloat64(clientCacheEntries)).To(Equal(1.0))
+	})
+
+	It("should evict the client when the secret changes", func() {
+		c1, err := cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		secret.ResourceVersion = "2"
+		c2, err := cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c2).NotTo(BeIdenticalTo(c1))
+		Expect(newClients).To(Equal(2))
+		Expect(testutil.ToFloat64(clientCacheEvictions.WithLabelValues("changed")) - changed).To(Equal(1.0))
+		Expect(cache.entries).To(HaveLen(1))
+	})
+
+	It("should keep clients of different secrets apart", func() {
+		c1, err := cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+		c2, err := cache.get(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "uid-2", ResourceVersion: "1"}}, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(c2).NotTo(BeIdenticalTo(c1))
+		Expect(cache.entries).To(HaveLen(2))
+	})
+
+	It("should evict idle clients", func() {
+		_, err := cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		now = now.Add(clientCacheIdleTimeout + time.Minute)
+		_, err = cache.get(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{UID: "uid-2", ResourceVersion: "1"}}, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(cache.entries).To(HaveLen(1))
+		Expect(cache.entries).To(HaveKey(BeEquivalentTo("uid-2")))
+		Expect(testutil.ToFloat64(clientCacheEvictions.WithLabelValues("idle")) - idle).To(Equal(1.0))
+	})
+
+	It("should not cache clients of secrets without UID", func() {
+		secret.UID = ""
+
+		_, err := cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+		_, err = cache.get(secret, []byte("kubeconfig"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(newClients).To(Equal(2))
+		Expect(cache.entries).To(BeEmpty())
+	})
+
+	It("should not cache failed clients", func() {
+		_, err := cache.get(secret, []byte("invalid"))
+		Expect(err).To(MatchError("invalid kubeconfig"))
+
+		Expect(cache.entries).To(BeEmpty())
+	})
+
+	Describe("#newMetalClient", func() {
+		It("should send requests through the proxy of the kubeconfig", func() {
+			var proxiedHosts []string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxiedHosts = append(proxiedHosts, r.URL.Host)
+				http.Error(w, "not found", http.StatusNotFound)
+			}))
+			DeferCleanup(proxy.Close)
+
+			kubeconfig := []byte(`apiVersion: v1
+kind: Config
+current-context: metal
+clusters:
+- name: metal
+  cluster:
+    server: http://metal.invalid
+    proxy-url: ` + proxy.URL + `
+contexts:
+- name: metal
+  context:
+    cluster: metal
+    user: metal
+users:
+- name: metal
+  user:
+    token: foo
+`)
+
+			metalClient, err := newMetalClient("shoot--foo--bar", kubeconfig)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metalClient.Get(context.Background(), client.ObjectKey{Namespace: "foo", Name: "bar"}, &corev1.Secret{})).NotTo(Succeed())
+			Expect(proxiedHosts).NotTo(BeEmpty())
+			Expect(proxiedHosts).To(HaveEach("metal.invalid"))
+		})
+	})
+})
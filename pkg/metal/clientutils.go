@@ -10,10 +10,10 @@ import (
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
-	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -21,6 +21,7 @@ var metalScheme = runtime.NewScheme()
 
 func init() {
 	utilruntime.Must(corev1.AddToScheme(metalScheme))
+	utilruntime.Must(authorizationv1.AddToScheme(metalScheme))
 	utilruntime.Must(extensionsv1alpha1.AddToScheme(metalScheme))
 }
 
@@ -32,24 +33,7 @@ func GetMetalClientAndNamespaceFromCloudProviderSecret(ctx context.Context, cl c
 	if err := cl.Get(ctx, secretKey, secret); err != nil {
 		return nil, "", fmt.Errorf("failed to get cloudprovider secret: %w", err)
 	}
-	kubeconfig, ok := secret.Data["kubeconfig"]
-	if !ok {
-		return nil, "", fmt.Errorf("could not find a kubeconfig in the cloudprovider secret")
-	}
-	namespace, ok := secret.Data["namespace"]
-	if !ok {
-		return nil, "", fmt.Errorf("could not find a namespace in the cloudprovider secret")
-	}
-	clientCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create rest config from cloudprovider secret: %w", err)
-	}
-	c, err := client.New(clientCfg, client.Options{Scheme: metalScheme})
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create client from cloudprovider secret: %w", err)
-	}
-
-	return c, string(namespace), nil
+	return GetMetalClientAndNamespaceFromSecret(secret)
 }
 
 // GetMetalClientAndNamespaceFromSecretRef extracts the <metalClient, metalNamespace> from the
@@ -59,25 +43,26 @@ func GetMetalClientAndNamespaceFromSecretRef(ctx context.Context, cl client.Clie
 	if err != nil {
 		return nil, "", err
 	}
+	return GetMetalClientAndNamespaceFromSecret(secret)
+}
 
+// GetMetalClientAndNamespaceFromSecret extracts the <metalClient, metalNamespace> from the given secret. Clients are
+// cached per secret and reused until the secret changes.
+func GetMetalClientAndNamespaceFromSecret(secret *corev1.Secret) (client.Client, string, error) {
 	if secret.Data == nil {
 		return nil, "", fmt.Errorf("secret does not contain any data")
 	}
-	kubeconfig, ok := secret.Data["kubeconfig"]
+	kubeconfig, ok := secret.Data[KubeConfigFieldName]
 	if !ok {
 		return nil, "", fmt.Errorf("could not find a kubeconfig in the secret")
 	}
-	namespace, ok := secret.Data["namespace"]
+	namespace, ok := secret.Data[NamespaceFieldName]
 	if !ok {
 		return nil, "", fmt.Errorf("could not find a namespace in the secret")
 	}
-	clientCfg, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	c, err := metalClientCache.get(secret, kubeconfig)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to create rest config from secret: %w", err)
-	}
-	c, err := client.New(clientCfg, client.Options{Scheme: metalScheme})
-	if err != nil {
-		return nil, "", fmt.Errorf("failed to create client from secret: %w", err)
+		return nil, "", err
 	}
 
 	return c, string(namespace), nil
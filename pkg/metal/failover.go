@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/yaml"
+)
+
+// failoverEndpointCooldown is the duration for which an unavailable server endpoint is only tried after all
+// available ones.
+const failoverEndpointCooldown = 30 * time.Second
+
+var failovers = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricsNamespace,
+	Subsystem: "api",
+	Name:      "failovers_total",
+	Help:      "Number of metal API requests which were served by a failover server endpoint instead of the server endpoint of the current context.",
+})
+
+func init() {
+	metrics.Registry.MustRegister(failovers)
+}
+
+// restConfigsFromKubeconfig returns the REST configs of the server endpoints in the given kubeconfig in the order in
+// which they are tried. The first one belongs to the current context, the further ones to the failover contexts, i.e.
+// the subsequent contexts of the kubeconfig with the same user and namespace.
+func restConfigsFromKubeconfig(kubeconfig []byte) ([]*rest.Config, error) {
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	currentContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		restConfig, err := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{}).ClientConfig()
+		if err != nil {
+			return nil, err
+		}
+		return []*rest.Config{restConfig}, nil
+	}
+
+	// The order of the contexts is only preserved by the versioned kubeconfig.
+	orderedConfig := &clientcmdv1.Config{}
+	if err := yaml.Unmarshal(kubeconfig, orderedConfig); err != nil {
+		return nil, err
+	}
+	contextNames := []string{config.CurrentContext}
+	for _, namedContext := range orderedConfig.Contexts {
+		kubeContext := config.Contexts[namedContext.Name]
+		if namedContext.Name == config.CurrentContext || kubeContext == nil {
+			continue
+		}
+		if kubeContext.AuthInfo == currentContext.AuthInfo && kubeContext.Namespace == currentContext.Namespace {
+			contextNames = append(contextNames, namedContext.Name)
+		}
+	}
+
+	var restConfigs []*rest.Config
+	for _, name := range contextNames {
+		restConfig, err := clientcmd.NewNonInteractiveClientConfig(*config, name, &clientcmd.ConfigOverrides{}, nil).ClientConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create rest config for context %q: %w", name, err)
+		}
+		restConfigs = append(restConfigs, restConfig)
+	}
+	return restConfigs, nil
+}
+
+// newFailoverHTTPClient returns an HTTP client which sends requests to the server endpoint of the first given REST
+// config and fails over to the server endpoints of the further ones if it is unavailable.
+func newFailoverHTTPClient(restConfigs []*rest.Config) (*http.Client, error) {
+	transport := &failoverTransport{now: time.Now}
+	for _, restConfig := range restConfigs {
+		endpointURL, err := url.Parse(restConfig.Host)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse server endpoint %q: %w", restConfig.Host, err)
+		}
+		endpointURL.Path = strings.TrimSuffix(endpointURL.Path, "/")
+		roundTripper, err := rest.TransportFor(restConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transport for server endpoint %q: %w", restConfig.Host, err)
+		}
+		transport.endpoints = append(transport.endpoints, &failoverEndpoint{
+			url:       endpointURL,
+			transport: roundTripper,
+		})
+	}
+	return &http.Client{Transport: transport, Timeout: restConfigs[0].Timeout}, nil
+}
+
+// failoverTransport sends requests to the first available server endpoint. Endpoints are not probed actively: an
+// endpoint is considered unavailable for failoverEndpointCooldown after a request to it failed without response, e.g.
+// because the connection was refused or timed out. Afterwards, requests are sent to it again, so that they fail back to
+// the preferred endpoint once it recovered.
+type failoverTransport struct {
+	mu        sync.Mutex
+	endpoints []*failoverEndpoint
+
+	now func() time.Time
+}
+
+type failoverEndpoint struct {
+	url              *url.URL
+	transport        http.RoundTripper
+	unavailableUntil time.Time
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var lastErr error
+	for i, endpoint := range t.orderedEndpoints() {
+		if i > 0 {
+			// Requests can only be retried if their body can be replayed.
+			if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+				break
+			}
+		}
+
+		endpointReq, err := t.endpointRequest(req, endpoint, i > 0)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := endpoint.transport.RoundTrip(endpointReq)
+		if err == nil {
+			t.setUnavailableUntil(endpoint, time.Time{})
+			if endpoint != t.endpoints[0] {
+				failovers.Inc()
+			}
+			return resp, nil
+		}
+		if req.Context().Err() != nil {
+			return nil, err
+		}
+		t.setUnavailableUntil(endpoint, t.now().Add(failoverEndpointCooldown))
+		lastErr = err
+	}
+	return nil, lastErr
+}
+
+// orderedEndpoints returns the available endpoints followed by the unavailable ones, each in their configured order.
+func (t *failoverTransport) orderedEndpoints() []*failoverEndpoint {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	endpoints := make([]*failoverEndpoint, 0, len(t.endpoints))
+	var unavailable []*failoverEndpoint
+	for _, endpoint := range t.endpoints {
+		if now.Before(endpoint.unavailableUntil) {
+			unavailable = append(unavailable, endpoint)
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	return append(endpoints, unavailable...)
+}
+
+func (t *failoverTransport) setUnavailableUntil(endpoint *failoverEndpoint, until time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	endpoint.unavailableUntil = until
+}
+
+// endpointRequest returns a copy of the given request, which is addressed to the first endpoint, for the given
+// endpoint.
+func (t *failoverTransport) endpointRequest(req *http.Request, endpoint *failoverEndpoint, retry bool) (*http.Request, error) {
+	endpointReq := req.Clone(req.Context())
+	endpointReq.Host = ""
+	endpointReq.URL.Scheme = endpoint.url.Scheme
+	endpointReq.URL.Host = endpoint.url.Host
+	endpointReq.URL.Path = endpoint.url.Path + strings.TrimPrefix(req.URL.Path, t.endpoints[0].url.Path)
+	endpointReq.URL.RawPath = ""
+	if retry && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		endpointReq.Body = body
+	}
+	return endpointReq, nil
+}
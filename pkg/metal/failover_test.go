@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var _ = Describe("Failover", func() {
+	Describe("#restConfigsFromKubeconfig", func() {
+		It("should return the server endpoints of the current and the failover contexts in their order", func() {
+			restConfigs, err := restConfigsFromKubeconfig([]byte(`apiVersion: v1
+kind: Config
+current-context: metal
+clusters:
+- name: metal-2
+  cluster:
+    server: https://metal-c.local
+- name: metal
+  cluster:
+    server: https://metal-a.local
+- name: metal-1
+  cluster:
+    server: https://metal-b.local
+- name: other
+  cluster:
+    server: https://other.local
+contexts:
+- name: metal-1
+  context:
+    cluster: metal-1
+    user: metal
+    namespace: foo
+- name: other
+  context:
+    cluster: other
+    user: other
+    namespace: foo
+- name: metal
+  context:
+    cluster: metal
+    user: metal
+    namespace: foo
+- name: metal-2
+  context:
+    cluster: metal-2
+    user: metal
+    namespace: foo
+users:
+- name: metal
+  user:
+    token: foo
+- name: other
+  user:
+    token: bar
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restConfigs).To(HaveLen(3))
+			Expect(restConfigs[0].Host).To(Equal("https://metal-a.local"))
+			Expect(restConfigs[1].Host).To(Equal("https://metal-b.local"))
+			Expect(restConfigs[2].Host).To(Equal("https://metal-c.local"))
+			Expect(restConfigs).To(HaveEach(HaveField("BearerToken", "foo")))
+		})
+	})
+
+	Describe("#failoverTransport", func() {
+		var (
+			now       time.Time
+			available map[string]bool
+			requests  []string
+			bodies    []string
+			transport *failoverTransport
+
+			failoverCount float64
+		)
+
+		newEndpoint := func(rawURL string) *failoverEndpoint {
+			endpointURL, err := url.Parse(rawURL)
+			Expect(err).NotTo(HaveOccurred())
+			return &failoverEndpoint{
+				url: endpointURL,
+				transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					requests = append(requests, req.URL.String())
+					if !available[req.URL.Host] {
+						return nil, errors.New("connection refused")
+					}
+					if req.Body != nil {
+						body, err := io.ReadAll(req.Body)
+						Expect(err).NotTo(HaveOccurred())
+						bodies = append(bodies, string(body))
+					}
+					return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+				}),
+			}
+		}
+
+		BeforeEach(func() {
+			now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			available = map[string]bool{"metal-a.local": true, "metal-b.local": true}
+			requests, bodies = nil, nil
+			transport = &failoverTransport{
+				endpoints: []*failoverEndpoint{
+					newEndpoint("https://metal-a.local/metal"),
+					newEndpoint("https://metal-b.local"),
+				},
+				now: func() time.Time { return now },
+			}
+
+			failoverCount = testutil.ToFloat64(failovers)
+		})
+
+		post := func() error {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://metal-a.local/metal/api/v1/namespaces/foo/secrets", strings.NewReader("secret"))
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				return err
+			}
+			return resp.Body.Close()
+		}
+
+		It("should send requests to the first endpoint if it is available", func() {
+			Expect(post()).To(Succeed())
+
+			Expect(requests).To(Equal([]string{"https://metal-a.local/metal/api/v1/namespaces/foo/secrets"}))
+			Expect(bodies).To(Equal([]string{"secret"}))
+			Expect(testutil.ToFloat64(failovers) - failoverCount).To(BeZero())
+		})
+
+		It("should fail over to the next endpoint and fail back after the cooldown", func() {
+			available["metal-a.local"] = false
+
+			Expect(post()).To(Succeed())
+			Expect(requests).To(Equal([]string{
+				"https://metal-a.local/metal/api/v1/namespaces/foo/secrets",
+				"https://metal-b.local/api/v1/namespaces/foo/secrets",
+			}))
+			Expect(bodies).To(Equal([]string{"secret"}))
+			Expect(testutil.ToFloat64(failovers) - failoverCount).To(Equal(1.0))
+
+			By("skipping the unavailable endpoint during the cooldown")
+			requests = nil
+			Expect(post()).To(Succeed())
+			Expect(requests).To(Equal([]string{"https://metal-b.local/api/v1/namespaces/foo/secrets"}))
+			Expect(testutil.ToFloat64(failovers) - failoverCount).To(Equal(2.0))
+
+			By("failing back to the first endpoint after the cooldown")
+			requests = nil
+			available["metal-a.local"] = true
+			now = now.Add(failoverEndpointCooldown)
+			Expect(post()).To(Succeed())
+			Expect(requests).To(Equal([]string{"https://metal-a.local/metal/api/v1/namespaces/foo/secrets"}))
+			Expect(testutil.ToFloat64(failovers) - failoverCount).To(Equal(2.0))
+		})
+
+		It("should return the error of the last endpoint if no endpoint is available", func() {
+			available = map[string]bool{}
+
+			Expect(post()).To(MatchError("connection refused"))
+			Expect(requests).To(HaveLen(2))
+		})
+
+		It("should not retry requests whose body cannot be replayed", func() {
+			available["metal-a.local"] = false
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, "https://metal-a.local/metal/api", io.NopCloser(strings.NewReader("secret")))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = transport.RoundTrip(req)
+			Expect(err).To(MatchError("connection refused"))
+			Expect(requests).To(HaveLen(1))
+		})
+	})
+})
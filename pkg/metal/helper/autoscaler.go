@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+
+	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+// FindMachineTypePriority takes a cloud profile config and the name of a machine type and returns the priority of
+// the machine type for the priority expander of the cluster-autoscaler. If the machine type or its priority is not
+// defined, nil is returned.
+func FindMachineTypePriority(cloudProfileConfig *api.CloudProfileConfig, machineType string) *int32 {
+	if cloudProfileConfig == nil {
+		return nil
+	}
+	for _, t := range cloudProfileConfig.MachineTypes {
+		if t.Name == machineType {
+			return t.Priority
+		}
+	}
+	return nil
+}
+
+// WorkerPoolPriorities returns the priorities of the given worker pools for the priority expander of the
+// cluster-autoscaler, which are derived from the priorities of their machine types. Worker pools of machine types
+// without priority get the priority 0. Nil is returned if none of the machine types has a priority, or if priorities
+// are configured for the worker pools of the Shoot, in which case Gardener configures the priority expander.
+func WorkerPoolPriorities(cloudProfileConfig *api.CloudProfileConfig, workers []gardencorev1beta1.Worker) map[string]int32 {
+	var (
+		priorities  = make(map[string]int32, len(workers))
+		hasPriority bool
+	)
+	for _, worker := range workers {
+		if worker.Priority != nil {
+			return nil
+		}
+		var priority int32
+		if p := FindMachineTypePriority(cloudProfileConfig, worker.Machine.Type); p != nil {
+			priority, hasPriority = *p, true
+		}
+		priorities[worker.Name] = priority
+	}
+	if !hasPriority {
+		return nil
+	}
+	return priorities
+}
+
+// PriorityExpanderConfig returns the configuration of the priority expander of the cluster-autoscaler for the given
+// priorities of the worker pools of the Shoot with the given technical ID. The configuration maps the priorities to
+// expressions matching the machine deployments of the worker pools in all zones.
+func PriorityExpanderConfig(technicalID string, priorities map[string]int32) string {
+	expressions := map[int32][]string{}
+	for _, name := range slices.Sorted(maps.Keys(priorities)) {
+		expressions[priorities[name]] = append(expressions[priorities[name]],
+			regexp.QuoteMeta(fmt.Sprintf("%s-%s-z", technicalID, name))+"[0-9]+$")
+	}
+
+	var config strings.Builder
+	for _, priority := range slices.SortedFunc(maps.Keys(expressions), func(a, b int32) int { return cmp.Compare(b, a) }) {
+		fmt.Fprintf(&config, "%d:\n", priority)
+		for _, expression := range expressions[priority] {
+			fmt.Fprintf(&config, "- '%s'\n", expression)
+		}
+	}
+	return config.String()
+}
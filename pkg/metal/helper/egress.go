@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+// defaultPorts are the ports of the region server or its proxy if the URL does not specify a port.
+var defaultPorts = map[string]int32{
+	"http":   80,
+	"https":  443,
+	"socks5": 1080,
+}
+
+// MetalAPIEgress is the egress traffic to the metal API of a region which the control plane components of a shoot
+// must be allowed to send.
+type MetalAPIEgress struct {
+	// CIDRs are the CIDRs of the region servers or their proxy.
+	CIDRs []string
+	// Ports are the TCP ports of the region servers or their proxy.
+	Ports []int32
+}
+
+// FindMetalAPIEgress takes a cloud profile config and the name of a region and returns the egress traffic to the
+// metal API of the region. The destination is the proxy of the region if one is configured and the region server and
+// its failover servers otherwise. The CIDRs of destinations addressed by an IP address are added to the configured
+// egress CIDRs of the region. If the region is not defined or no CIDRs are known, nil is returned.
+func FindMetalAPIEgress(cloudProfileConfig *api.CloudProfileConfig, region string) (*MetalAPIEgress, error) {
+	if cloudProfileConfig == nil {
+		return nil, nil
+	}
+	for _, regionConfig := range cloudProfileConfig.RegionConfigs {
+		if regionConfig.Name != region {
+			continue
+		}
+
+		endpoints := append([]string{regionConfig.Server}, regionConfig.FailoverServers...)
+		if len(regionConfig.ProxyURL) > 0 {
+			endpoints = []string{regionConfig.ProxyURL}
+		}
+
+		cidrs := sets.New[string]()
+		ports := sets.New[int32]()
+		for _, endpoint := range endpoints {
+			u, err := url.Parse(endpoint)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse metal API endpoint %q of region %q: %w", endpoint, region, err)
+			}
+
+			port, ok := defaultPorts[u.Scheme]
+			if !ok {
+				port = defaultPorts["https"]
+			}
+			if p := u.Port(); len(p) > 0 {
+				parsed, err := strconv.ParseInt(p, 10, 32)
+				if err != nil {
+					return nil, fmt.Errorf("failed to parse port of metal API endpoint %q of region %q: %w", endpoint, region, err)
+				}
+				port = int32(parsed)
+			}
+			ports.Insert(port)
+
+			if ip := net.ParseIP(u.Hostname()); ip != nil {
+				if ip.To4() != nil {
+					cidrs.Insert(ip.String() + "/32")
+				} else {
+					cidrs.Insert(ip.String() + "/128")
+				}
+			}
+		}
+		cidrs.Insert(regionConfig.EgressCIDRs...)
+		if cidrs.Len() == 0 {
+			return nil, nil
+		}
+
+		return &MetalAPIEgress{
+			CIDRs: sets.List(cidrs),
+			Ports: sets.List(ports),
+		}, nil
+	}
+	return nil, nil
+}
@@ -6,6 +6,7 @@ package helper
 import (
 	"fmt"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/utils/ptr"
 
 	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
@@ -43,3 +44,64 @@ func FindImageFromCloudProfile(cloudProfileConfig *api.CloudProfileConfig, image
 
 	return "", fmt.Errorf("could not find an image for name %q and in version %q", imageName, imageVersion)
 }
+
+// FindMachineTypeVolume takes a cloud profile config, the name of a machine type and the requested volume type and size.
+// If the machine type does not define any volumes, nil is returned. Otherwise, the smallest volume of the given type
+// whose size is at least the requested size is returned. If no such volume exists then an error is returned.
+func FindMachineTypeVolume(cloudProfileConfig *api.CloudProfileConfig, machineType, volumeType string, size resource.Quantity) (*api.MachineTypeVolume, error) {
+	if cloudProfileConfig == nil {
+		return nil, nil
+	}
+
+	var found *api.MachineTypeVolume
+	for _, t := range cloudProfileConfig.MachineTypes {
+		if t.Name != machineType {
+			continue
+		}
+		if len(t.Volumes) == 0 {
+			return nil, nil
+		}
+		for i, volume := range t.Volumes {
+			if volume.Type != volumeType || volume.Size.Cmp(size) < 0 {
+				continue
+			}
+			if found == nil || volume.Size.Cmp(found.Size) < 0 {
+				found = &t.Volumes[i]
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("machine type %q does not offer a volume of type %q with a size of at least %s", machineType, volumeType, size.String())
+		}
+		return found, nil
+	}
+
+	return nil, nil
+}
+
+// FindMachineTypeCapacity takes a cloud profile config and the name of a machine type and returns the capacity of the
+// servers of the machine type. If the machine type or its capacity is not defined, nil is returned.
+func FindMachineTypeCapacity(cloudProfileConfig *api.CloudProfileConfig, machineType string) *api.MachineTypeCapacity {
+	if cloudProfileConfig == nil {
+		return nil
+	}
+	for _, t := range cloudProfileConfig.MachineTypes {
+		if t.Name == machineType {
+			return t.Capacity
+		}
+	}
+	return nil
+}
+
+// FindMachineTypeServerLabels takes a cloud profile config and the name of a machine type and returns the labels which
+// select the servers of the machine type. If the machine type or its server labels are not defined, nil is returned.
+func FindMachineTypeServerLabels(cloudProfileConfig *api.CloudProfileConfig, machineType string) map[string]string {
+	if cloudProfileConfig == nil {
+		return nil
+	}
+	for _, t := range cloudProfileConfig.MachineTypes {
+		if t.Name == machineType {
+			return t.ServerLabels
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"fmt"
+	"slices"
+
+	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+// SelectStorageClasses returns the StorageClasses of the cloud profile which are deployed into a shoot with the given
+// storage configuration, together with the name of its default StorageClass. If the storage configuration does not
+// select any StorageClass, all StorageClasses of the cloud profile are returned.
+func SelectStorageClasses(storage *api.StorageConfig, available api.StorageClasses) ([]api.StorageClass, string, error) {
+	var (
+		storageClasses      []api.StorageClass
+		defaultStorageClass string
+	)
+	if available.Default != nil {
+		storageClasses = append(storageClasses, *available.Default)
+		defaultStorageClass = available.Default.Name
+	}
+	storageClasses = append(storageClasses, available.Additional...)
+
+	if len(storage.StorageClasses) > 0 {
+		var selected []api.StorageClass
+		for _, name := range storage.StorageClasses {
+			idx := slices.IndexFunc(storageClasses, func(sc api.StorageClass) bool { return sc.Name == name })
+			if idx < 0 {
+				return nil, "", fmt.Errorf("storage class %q is not defined in the cloud profile", name)
+			}
+			selected = append(selected, storageClasses[idx])
+		}
+		storageClasses = selected
+	}
+	if storage.DefaultStorageClass != nil {
+		defaultStorageClass = *storage.DefaultStorageClass
+	}
+	return storageClasses, defaultStorageClass, nil
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package helper
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateVariables are the variables which can be used in the templated fields of a WorkerConfig, i.e. the
+// values of Metadata and ExtraIgnition.Raw. Referencing any other variable is an error.
+type TemplateVariables struct {
+	// ShootName is the name of the Shoot.
+	ShootName string
+	// ProjectName is the name of the project of the Shoot.
+	ProjectName string
+	// TechnicalID is the technical ID of the Shoot, i.e. the name of its namespace in the seed.
+	TechnicalID string
+	// PoolName is the name of the worker pool.
+	PoolName string
+	// Zone is the zone the machines are created in.
+	Zone string
+	// Region is the region of the Shoot.
+	Region string
+	// NodeCIDR is the CIDR of the node network of the Shoot.
+	NodeCIDR string
+	// Networks maps the names of the networks of the InfrastructureConfig to their CIDRs.
+	Networks map[string]string
+}
+
+// ExampleTemplateVariables returns TemplateVariables with placeholder values, which can be used to check that a
+// template only references known variables.
+func ExampleTemplateVariables() *TemplateVariables {
+	return &TemplateVariables{
+		ShootName:   "shoot",
+		ProjectName: "project",
+		TechnicalID: "shoot--project--shoot",
+		PoolName:    "pool",
+		Zone:        "zone",
+		Region:      "region",
+		NodeCIDR:    "10.0.0.0/24",
+		Networks:    map[string]string{},
+	}
+}
+
+// RenderTemplate renders the given text as Go template with the given variables. Text without template actions is
+// returned as is. Templates may only print variables, e.g. {{ .Zone }} or {{ index .Networks "name" }}; functions,
+// control structures and pipelines are rejected.
+func RenderTemplate(text string, variables *TemplateVariables) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Funcs(template.FuncMap{}).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+	if err := validateTemplateNode(tmpl.Root); err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, variables); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// validateTemplateNode checks that the given template node only consists of text and actions printing a variable.
+// The builtin functions of text/template cannot be removed from the FuncMap, so they are rejected here.
+func validateTemplateNode(node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		for _, child := range n.Nodes {
+			if err := validateTemplateNode(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *parse.TextNode:
+		return nil
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) == 0 && len(n.Pipe.Cmds) == 1 && isVariableReference(n.Pipe.Cmds[0]) {
+			return nil
+		}
+		return fmt.Errorf("unsupported action %s, only variables like {{ .Zone }} or {{ index .Networks \"name\" }} are allowed", n)
+	default:
+		return fmt.Errorf("unsupported action %s, only variables like {{ .Zone }} or {{ index .Networks \"name\" }} are allowed", n)
+	}
+}
+
+// isVariableReference returns whether the given command references a variable, either as field chain or by looking up
+// a key of a map variable with index.
+func isVariableReference(cmd *parse.CommandNode) bool {
+	switch len(cmd.Args) {
+	case 1:
+		_, ok := cmd.Args[0].(*parse.FieldNode)
+		return ok
+	case 3:
+		identifier, ok := cmd.Args[0].(*parse.IdentifierNode)
+		if !ok || identifier.Ident != "index" {
+			return false
+		}
+		_, isField := cmd.Args[1].(*parse.FieldNode)
+		_, isString := cmd.Args[2].(*parse.StringNode)
+		return isField && isString
+	default:
+		return false
+	}
+}
+
+// RenderTemplateMap renders the values of the given map as Go templates with the given variables.
+func RenderTemplateMap(m map[string]string, variables *TemplateVariables) (map[string]string, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	rendered := make(map[string]string, len(m))
+	for key, value := range m {
+		renderedValue, err := RenderTemplate(value, variables)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render value of key %s: %w", key, err)
+		}
+		rendered[key] = renderedValue
+	}
+	return rendered, nil
+}
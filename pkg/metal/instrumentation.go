@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	apiRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: MetricsNamespace,
+		Subsystem: "api",
+		Name:      "request_duration_seconds",
+		Help:      "Latency of metal API requests, partitioned by shoot namespace, verb and resource.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"namespace", "verb", "resource"})
+	apiRequestErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: MetricsNamespace,
+		Subsystem: "api",
+		Name:      "request_errors_total",
+		Help:      "Number of failed metal API requests, partitioned by shoot namespace, verb, resource and code. The code is the HTTP status code or 'error' if no response was received.",
+	}, []string{"namespace", "verb", "resource", "code"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(apiRequestDuration, apiRequestErrors)
+}
+
+// DeleteAPIRequestMetrics deletes the metal API request series of the shoot in the given namespace, e.g. after its
+// worker was deleted.
+func DeleteAPIRequestMetrics(namespace string) {
+	labels := prometheus.Labels{"namespace": namespace}
+	apiRequestDuration.DeletePartialMatch(labels)
+	apiRequestErrors.DeletePartialMatch(labels)
+}
+
+// instrumentedTransport records the latency and errors of the metal API requests of the shoot in the given namespace.
+type instrumentedTransport struct {
+	namespace string
+	transport http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *instrumentedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	verb, resource := requestVerbAndResource(req)
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	apiRequestDuration.WithLabelValues(t.namespace, verb, resource).Observe(time.Since(start).Seconds())
+
+	switch {
+	case err != nil:
+		apiRequestErrors.WithLabelValues(t.namespace, verb, resource, "error").Inc()
+	case resp.StatusCode >= http.StatusBadRequest:
+		apiRequestErrors.WithLabelValues(t.namespace, verb, resource, strconv.Itoa(resp.StatusCode)).Inc()
+	}
+	return resp, err
+}
+
+// requestVerbAndResource returns the Kubernetes API verb and the resource of the given request, e.g. `list` and
+// `serverclaims.metal.ironcore.dev`. Discovery requests have an empty resource.
+func requestVerbAndResource(req *http.Request) (string, string) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	var group string
+	switch {
+	case len(parts) >= 2 && parts[0] == "api":
+		parts = parts[2:]
+	case len(parts) >= 3 && parts[0] == "apis":
+		group = parts[1]
+		parts = parts[3:]
+	default:
+		parts = nil
+	}
+	if len(parts) >= 3 && parts[0] == "namespaces" {
+		parts = parts[2:]
+	}
+
+	var resource string
+	if len(parts) > 0 {
+		resource = parts[0]
+		if group != "" {
+			resource += "." + group
+		}
+	}
+	hasName := len(parts) > 1
+
+	switch req.Method {
+	case http.MethodGet:
+		if req.URL.Query().Get("watch") == "true" {
+			return "watch", resource
+		}
+		if hasName {
+			return "get", resource
+		}
+		return "list", resource
+	case http.MethodPost:
+		return "create", resource
+	case http.MethodPut:
+		return "update", resource
+	case http.MethodPatch:
+		return "patch", resource
+	case http.MethodDelete:
+		if hasName {
+			return "delete", resource
+		}
+		return "deletecollection", resource
+	default:
+		return strings.ToLower(req.Method), resource
+	}
+}
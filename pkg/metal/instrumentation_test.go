@@ -0,0 +1,105 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+var _ = Describe("Instrumentation", func() {
+	DescribeTable("#requestVerbAndResource",
+		func(method, url, expectedVerb, expectedResource string) {
+			req, err := http.NewRequestWithContext(context.Background(), method, url, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			verb, resource := requestVerbAndResource(req)
+			Expect(verb).To(Equal(expectedVerb))
+			Expect(resource).To(Equal(expectedResource))
+		},
+		Entry("discovery", http.MethodGet, "https://metal.local/apis/metal.ironcore.dev/v1alpha1", "list", ""),
+		Entry("get namespace", http.MethodGet, "https://metal.local/api/v1/namespaces/foo", "get", "namespaces"),
+		Entry("list secrets", http.MethodGet, "https://metal.local/api/v1/namespaces/foo/secrets", "list", "secrets"),
+		Entry("watch serverclaims", http.MethodGet, "https://metal.local/apis/metal.ironcore.dev/v1alpha1/namespaces/foo/serverclaims?watch=true", "watch", "serverclaims.metal.ironcore.dev"),
+		Entry("create serverclaim", http.MethodPost, "https://metal.local/apis/metal.ironcore.dev/v1alpha1/namespaces/foo/serverclaims", "create", "serverclaims.metal.ironcore.dev"),
+		Entry("update serverclaim status", http.MethodPut, "https://metal.local/apis/metal.ironcore.dev/v1alpha1/namespaces/foo/serverclaims/bar/status", "update", "serverclaims.metal.ironcore.dev"),
+		Entry("patch secret", http.MethodPatch, "https://metal.local/api/v1/namespaces/foo/secrets/bar", "patch", "secrets"),
+		Entry("delete serverclaim", http.MethodDelete, "https://metal.local/apis/metal.ironcore.dev/v1alpha1/namespaces/foo/serverclaims/bar", "delete", "serverclaims.metal.ironcore.dev"),
+		Entry("delete serverclaims", http.MethodDelete, "https://metal.local/apis/metal.ironcore.dev/v1alpha1/namespaces/foo/serverclaims", "deletecollection", "serverclaims.metal.ironcore.dev"),
+		Entry("cluster-scoped review", http.MethodPost, "https://metal.local/apis/authorization.k8s.io/v1/selfsubjectaccessreviews", "create", "selfsubjectaccessreviews.authorization.k8s.io"),
+	)
+
+	Describe("#instrumentedTransport", func() {
+		var (
+			resp      *http.Response
+			respErr   error
+			transport *instrumentedTransport
+		)
+
+		BeforeEach(func() {
+			resp, respErr = nil, nil
+			transport = &instrumentedTransport{
+				namespace: "shoot--foo--bar",
+				transport: roundTripperFunc(func(*http.Request) (*http.Response, error) {
+					return resp, respErr
+				}),
+			}
+		})
+
+		roundTrip := func() {
+			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://metal.local/api/v1/namespaces/foo/secrets/bar", nil)
+			Expect(err).NotTo(HaveOccurred())
+			_, _ = transport.RoundTrip(req)
+		}
+
+		It("should count failed requests by their status code", func() {
+			errs := apiRequestErrors.WithLabelValues("shoot--foo--bar", "get", "secrets", "503")
+			count := testutil.ToFloat64(errs)
+
+			resp = &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			roundTrip()
+			resp = &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+			roundTrip()
+
+			Expect(testutil.ToFloat64(errs) - count).To(Equal(1.0))
+		})
+
+		It("should count requests without response", func() {
+			errs := apiRequestErrors.WithLabelValues("shoot--foo--bar", "get", "secrets", "error")
+			count := testutil.ToFloat64(errs)
+
+			respErr = errors.New("connection refused")
+			roundTrip()
+
+			Expect(testutil.ToFloat64(errs) - count).To(Equal(1.0))
+		})
+
+		It("should record the latency of requests", func() {
+			before := testutil.CollectAndCount(apiRequestDuration)
+
+			resp = &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			transport.namespace = "shoot--foo--latency"
+			roundTrip()
+
+			Expect(testutil.CollectAndCount(apiRequestDuration)).To(Equal(before + 1))
+		})
+
+		It("should delete the series of a shoot", func() {
+			resp = &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+			transport.namespace = "shoot--foo--deleted"
+			roundTrip()
+			durations, errs := testutil.CollectAndCount(apiRequestDuration), testutil.CollectAndCount(apiRequestErrors)
+
+			DeleteAPIRequestMetrics("shoot--foo--deleted")
+
+			Expect(testutil.CollectAndCount(apiRequestDuration)).To(Equal(durations - 1))
+			Expect(testutil.CollectAndCount(apiRequestErrors)).To(Equal(errs - 1))
+		})
+	})
+})
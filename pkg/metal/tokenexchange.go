@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+const (
+	// grantTypeTokenExchange is the OAuth 2.0 grant type of token exchange requests (RFC 8693).
+	grantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	// tokenTypeJWT is the OAuth 2.0 token type of JSON web tokens, i.e. workload identity tokens.
+	tokenTypeJWT = "urn:ietf:params:oauth:token-type:jwt"
+	// tokenTypeAccessToken is the OAuth 2.0 token type of access tokens.
+	tokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+	tokenExchangeTimeout = 10 * time.Second
+)
+
+// tokenExchangeResponse is the successful response of a token exchange endpoint.
+type tokenExchangeResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int64  `json:"expires_in,omitempty"`
+}
+
+// ExchangedToken is a token of the metal cluster obtained from a token exchange endpoint.
+type ExchangedToken struct {
+	// Token is the access token of the metal cluster.
+	Token string
+	// ExpiresIn is the lifetime of the token. It is zero if the token exchange endpoint does not report it.
+	ExpiresIn time.Duration
+}
+
+// ExchangeToken exchanges the given workload identity token for a token of the metal cluster at the OAuth 2.0 token
+// exchange endpoint (RFC 8693) of the given configuration. The endpoint is reached through the given proxy URL if it
+// is set, otherwise through the proxy of the environment.
+func ExchangeToken(ctx context.Context, config *apismetal.TokenExchangeConfig, proxyURL string, subjectToken string) (*ExchangedToken, error) {
+	httpClient, err := tokenExchangeHTTPClient(config, proxyURL)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":           {grantTypeTokenExchange},
+		"subject_token":        {subjectToken},
+		"subject_token_type":   {tokenTypeJWT},
+		"requested_token_type": {tokenTypeAccessToken},
+	}
+	if config.Audience != "" {
+		form.Set("audience", config.Audience)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenExchangeTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create token exchange request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read token exchange response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token exchange failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	tokenResponse := &tokenExchangeResponse{}
+	if err := json.Unmarshal(body, tokenResponse); err != nil {
+		return nil, fmt.Errorf("failed to decode token exchange response: %w", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("token exchange response does not contain an access token")
+	}
+	return &ExchangedToken{
+		Token:     tokenResponse.AccessToken,
+		ExpiresIn: time.Duration(tokenResponse.ExpiresIn) * time.Second,
+	}, nil
+}
+
+func tokenExchangeHTTPClient(config *apismetal.TokenExchangeConfig, proxyURL string) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse proxy URL of the token exchange endpoint: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+
+	if len(config.CertificateAuthorityData) > 0 {
+		rootCAs := x509.NewCertPool()
+		if !rootCAs.AppendCertsFromPEM(config.CertificateAuthorityData) {
+			return nil, fmt.Errorf("failed to parse certificate authority data of the token exchange endpoint")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs, MinVersion: tls.VersionTLS12}
+	}
+	return &http.Client{Transport: transport}, nil
+}
@@ -0,0 +1,72 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package metal
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+var _ = Describe("TokenExchange", func() {
+	Describe("#ExchangeToken", func() {
+		var ctx = context.TODO()
+
+		It("should exchange the token and return its lifetime", func() {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer GinkgoRecover()
+				Expect(r.ParseForm()).To(Succeed())
+				Expect(r.PostForm.Get("grant_type")).To(Equal("urn:ietf:params:oauth:grant-type:token-exchange"))
+				Expect(r.PostForm.Get("subject_token")).To(Equal("workload-identity-token"))
+				Expect(r.PostForm.Get("audience")).To(Equal("metal"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"metal-token","issued_token_type":"urn:ietf:params:oauth:token-type:access_token","token_type":"Bearer","expires_in":3600}`))
+			}))
+			DeferCleanup(server.Close)
+
+			config := &apismetal.TokenExchangeConfig{
+				URL:                      server.URL + "/token",
+				Audience:                 "metal",
+				CertificateAuthorityData: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}),
+			}
+
+			Expect(ExchangeToken(ctx, config, "", "workload-identity-token")).To(Equal(&ExchangedToken{
+				Token:     "metal-token",
+				ExpiresIn: time.Hour,
+			}))
+		})
+
+		It("should reach the token exchange endpoint through the given proxy", func() {
+			var proxiedURL string
+			proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				proxiedURL = r.URL.String()
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token":"metal-token"}`))
+			}))
+			DeferCleanup(proxy.Close)
+
+			config := &apismetal.TokenExchangeConfig{URL: "http://sts.metal.invalid/token"}
+
+			Expect(ExchangeToken(ctx, config, proxy.URL, "workload-identity-token")).To(Equal(&ExchangedToken{Token: "metal-token"}))
+			Expect(proxiedURL).To(Equal("http://sts.metal.invalid/token"))
+		})
+
+		It("should fail if the token exchange fails", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "invalid subject token", http.StatusBadRequest)
+			}))
+			DeferCleanup(server.Close)
+
+			_, err := ExchangeToken(ctx, &apismetal.TokenExchangeConfig{URL: server.URL + "/token"}, "", "workload-identity-token")
+			Expect(err).To(MatchError(ContainSubstring("token exchange failed with status 400: invalid subject token")))
+		})
+	})
+})
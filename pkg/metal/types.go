@@ -26,6 +26,8 @@ const (
 	MetalLoadBalancerControllerSpeakerImageName = "metal-load-balancer-controller-speaker"
 	// MetalLoadBalancerControllerManagerImageName is the name of the metal load balancer controller manager to deploy to the seed.
 	MetalLoadBalancerControllerManagerImageName = "metal-load-balancer-controller-manager"
+	// LocalVolumeProvisionerImageName is the name of the local static volume provisioner to deploy to the shoot.
+	LocalVolumeProvisionerImageName = "local-volume-provisioner"
 
 	// UsernameFieldName is the field in a secret where the namespace is stored at.
 	UsernameFieldName = "username"
@@ -35,6 +37,25 @@ const (
 	KubeConfigFieldName = "kubeconfig"
 	// TokenFieldName is containing the token to access an metal cluster.
 	TokenFieldName = "token"
+	// ClientCertificateFieldName is containing the PEM encoded client certificate to access an metal cluster.
+	ClientCertificateFieldName = "tls.crt"
+	// ClientKeyFieldName is containing the PEM encoded private key of the client certificate.
+	ClientKeyFieldName = "tls.key"
+	// OIDCIssuerURLFieldName is containing the URL of the OIDC issuer trusted by the metal cluster.
+	OIDCIssuerURLFieldName = "idp-issuer-url"
+	// OIDCClientIDFieldName is containing the OIDC client ID.
+	OIDCClientIDFieldName = "client-id"
+	// OIDCClientSecretFieldName is containing the OIDC client secret.
+	OIDCClientSecretFieldName = "client-secret"
+	// OIDCRefreshTokenFieldName is containing the OIDC refresh token used to obtain ID tokens.
+	OIDCRefreshTokenFieldName = "refresh-token"
+	// OIDCCertificateAuthorityDataFieldName is containing the PEM encoded CA bundle of the OIDC issuer.
+	OIDCCertificateAuthorityDataFieldName = "idp-certificate-authority-data"
+	// OIDCIDTokenFieldName is the field of the oidc auth provider containing a cached ID token. It is not accepted in
+	// cloudprovider secrets, see ValidateCloudProviderSecret.
+	OIDCIDTokenFieldName = "id-token"
+	// ExchangedTokenFieldName is containing the metal cluster token exchanged for the workload identity token.
+	ExchangedTokenFieldName = "exchanged-token"
 	// ClusterFieldName is the name of the cluster field
 	ClusterFieldName = "clusterName"
 	// LabelsFieldName is the name of the labels field
@@ -53,12 +74,29 @@ const (
 	MetaDataFieldName = "metaData"
 	// IPAMConfigFieldName is the name of the ipamConfig field
 	IPAMConfigFieldName = "ipamConfig"
+	// VolumeFieldName is the name of the volume field
+	VolumeFieldName = "volume"
+	// VolumeTypeFieldName is the name of the type field of the volume
+	VolumeTypeFieldName = "type"
+	// VolumeSizeFieldName is the name of the size field of the volume
+	VolumeSizeFieldName = "size"
+	// NodeNamePolicyFieldName is the name of the nodeNamePolicy field
+	NodeNamePolicyFieldName = "nodeNamePolicy"
 	// ClusterNameLabel is the name is the label key of the cluster name
 	ClusterNameLabel = "extension.metal.dev/cluster-name"
+	// VolumeTypeLabel is the label key of the volume type of a worker node
+	VolumeTypeLabel = "storage.metal.ironcore.dev/volume-type"
 	// LocalMetalAPIAnnotation is the name of the annotation to mark a seed, which contains a local metal API shoot
 	LocalMetalAPIAnnotation = "metal.ironcore.dev/local-metal-api"
+	// TokenExchangeRenewTimestampAnnotation is the name of the annotation of cloudprovider secrets which contains the
+	// time after which the exchanged metal cluster token has to be renewed.
+	TokenExchangeRenewTimestampAnnotation = "metal.ironcore.dev/token-exchange-renew-timestamp"
 	// AllowEgressToIstioIngressLabel is the label key to allow egress to the istio ingress gateway
 	AllowEgressToIstioIngressLabel = "networking.resources.gardener.cloud/to-all-istio-ingresses-istio-ingressgateway-tcp-9443"
+	// AllowEgressToMetalAPILabel is the label key to allow egress to the metal API of the region of a shoot
+	AllowEgressToMetalAPILabel = "networking.metal.ironcore.dev/to-metal-api"
+	// MetalAPIEgressNetworkPolicyName is the name of the network policy allowing egress to the metal API
+	MetalAPIEgressNetworkPolicyName = "egress-to-metal-api"
 
 	// CloudProviderConfigName is the name of the secret containing the cloud provider config.
 	CloudProviderConfigName = "cloud-provider-config"
@@ -80,8 +118,17 @@ const (
 	MetalLoadBalancerControllerSpeakerName = "metal-load-balancer-controller-speaker"
 	// MetalLoadBalancerControllerManagerName is a constant for the name of the metal load balancer controller manager.
 	MetalLoadBalancerControllerManagerName = "metal-load-balancer-controller-manager"
+	// LocalVolumeProvisionerName is a constant for the name of the local static volume provisioner.
+	LocalVolumeProvisionerName = "local-volume-provisioner"
+	// LocalStorageProvisioner is the provisioner of StorageClasses backed by local static volumes.
+	LocalStorageProvisioner = "kubernetes.io/no-provisioner"
+	// LocalVolumeHostDirPrefix is the directory on the nodes under which local static volumes of a volume type are discovered.
+	LocalVolumeHostDirPrefix = "/mnt/disks"
 	// MachineControllerManagerName is a constant for the name of the machine-controller-manager.
 	MachineControllerManagerName = "machine-controller-manager"
+	// ClusterAutoscalerPriorityExpanderName is a constant for the name of the ConfigMap configuring the priority
+	// expander of the cluster-autoscaler.
+	ClusterAutoscalerPriorityExpanderName = "cluster-autoscaler-priority-expander"
 	// ShootCalicoNetworkType is the network type for calico in a shoot.
 	ShootCalicoNetworkType = "calico"
 	// MachineControllerManagerVpaName is the name of the VerticalPodAutoscaler of the machine-controller-manager deployment.
@@ -4,11 +4,14 @@
 package cloudprovider
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
 
 	"github.com/gardener/gardener/extensions/pkg/webhook/cloudprovider"
 	gcontext "github.com/gardener/gardener/extensions/pkg/webhook/context"
+	securityv1alpha1constants "github.com/gardener/gardener/pkg/apis/security/v1alpha1/constants"
 	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -19,6 +22,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	metalvalidation "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/validation"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
 )
 
@@ -31,6 +36,16 @@ func NewEnsurer(logger logr.Logger, mgr manager.Manager) cloudprovider.Ensurer {
 	}
 }
 
+const (
+	// workloadIdentityAuthInfoName is the name of the user in kubeconfigs built from workload identity tokens.
+	workloadIdentityAuthInfoName = "workload-identity"
+	// clientCertificateAuthInfoName is the default name of the user in kubeconfigs built from client certificates.
+	clientCertificateAuthInfoName = "client-certificate"
+	// oidcAuthInfoName is the default name of the user in kubeconfigs built from OIDC credentials. It is also the name
+	// of the client-go auth provider.
+	oidcAuthInfoName = "oidc"
+)
+
 type ensurer struct {
 	logger  logr.Logger
 	client  client.Client
@@ -40,31 +55,135 @@ type ensurer struct {
 // EnsureCloudProviderSecret ensures that cloudprovider secret contains
 // the shared credentials file.
 func (e *ensurer) EnsureCloudProviderSecret(ctx context.Context, gctx gcontext.GardenContext, newCloudProviderSecret, _ *corev1.Secret) error {
-	token, ok := newCloudProviderSecret.Data[metal.TokenFieldName]
-	if !ok {
-		return fmt.Errorf("could not mutate cloudprovider secret as %q field is missing", metal.TokenFieldName)
+	if newCloudProviderSecret.Labels[securityv1alpha1constants.LabelPurpose] == securityv1alpha1constants.LabelPurposeWorkloadIdentityTokenRequestor {
+		return e.ensureWorkloadIdentityKubeconfig(ctx, gctx, newCloudProviderSecret)
 	}
-	namespace, ok := newCloudProviderSecret.Data[metal.NamespaceFieldName]
-	if !ok {
-		return fmt.Errorf("could not mutate cloudprovider secret as %q field is missing", metal.NamespaceFieldName)
+
+	if err := metalvalidation.ValidateCloudProviderSecret(newCloudProviderSecret); err != nil {
+		return fmt.Errorf("could not mutate cloudprovider secret: %w", err)
+	}
+
+	kubeconfig, err := e.kubeconfig(ctx, gctx, cloudProviderSecretAuthInfo(newCloudProviderSecret), string(newCloudProviderSecret.Data[metal.NamespaceFieldName]))
+	if err != nil {
+		return err
+	}
+
+	newCloudProviderSecret.Data[metal.KubeConfigFieldName] = kubeconfig
+	return nil
+}
+
+// cloudProviderSecretAuthInfo returns the kubeconfig user for the credentials of a validated cloudprovider secret.
+// Exec credential plugins are not supported, as the components using the kubeconfig do not ship any plugin binaries.
+func cloudProviderSecretAuthInfo(secret *corev1.Secret) clientcmdv1.NamedAuthInfo {
+	data := secret.Data
+	switch {
+	case len(data[metal.ClientCertificateFieldName]) > 0:
+		return clientcmdv1.NamedAuthInfo{
+			Name: authInfoName(secret, clientCertificateAuthInfoName),
+			AuthInfo: clientcmdv1.AuthInfo{
+				ClientCertificateData: data[metal.ClientCertificateFieldName],
+				ClientKeyData:         data[metal.ClientKeyFieldName],
+			},
+		}
+	case len(data[metal.OIDCIssuerURLFieldName]) > 0:
+		config := map[string]string{
+			metal.OIDCIssuerURLFieldName:    string(data[metal.OIDCIssuerURLFieldName]),
+			metal.OIDCClientIDFieldName:     string(data[metal.OIDCClientIDFieldName]),
+			metal.OIDCRefreshTokenFieldName: string(data[metal.OIDCRefreshTokenFieldName]),
+		}
+		if clientSecret, ok := data[metal.OIDCClientSecretFieldName]; ok {
+			config[metal.OIDCClientSecretFieldName] = string(clientSecret)
+		}
+		if caData, ok := data[metal.OIDCCertificateAuthorityDataFieldName]; ok {
+			// The oidc auth provider expects the CA bundle base64 encoded.
+			config[metal.OIDCCertificateAuthorityDataFieldName] = base64.StdEncoding.EncodeToString(caData)
+		}
+		return clientcmdv1.NamedAuthInfo{
+			Name: authInfoName(secret, oidcAuthInfoName),
+			AuthInfo: clientcmdv1.AuthInfo{
+				AuthProvider: &clientcmdv1.AuthProviderConfig{
+					Name:   oidcAuthInfoName,
+					Config: config,
+				},
+			},
+		}
+	default:
+		return clientcmdv1.NamedAuthInfo{
+			Name: string(data[metal.UsernameFieldName]),
+			AuthInfo: clientcmdv1.AuthInfo{
+				Token: string(data[metal.TokenFieldName]),
+			},
+		}
+	}
+}
+
+func authInfoName(secret *corev1.Secret, defaultName string) string {
+	if username := secret.Data[metal.UsernameFieldName]; len(username) > 0 {
+		return string(username)
 	}
-	username, ok := newCloudProviderSecret.Data[metal.UsernameFieldName]
+	return defaultName
+}
+
+// ensureWorkloadIdentityKubeconfig ensures that a cloudprovider secret of a WorkloadIdentity contains a kubeconfig for
+// the metal cluster. The workload identity token is either trusted by the metal cluster as OIDC token or exchanged
+// for a metal cluster token at the token exchange endpoint of the WorkloadIdentityConfig. The exchange is done by the
+// token exchange controller, which stores the exchanged token in the secret, so that admission does not depend on the
+// availability of the token exchange endpoint.
+func (e *ensurer) ensureWorkloadIdentityKubeconfig(ctx context.Context, gctx gcontext.GardenContext, newCloudProviderSecret *corev1.Secret) error {
+	rawConfig, ok := newCloudProviderSecret.Data[securityv1alpha1constants.DataKeyConfig]
 	if !ok {
-		return fmt.Errorf("could not mutate cloud provider secret as %q fied is missing", metal.UsernameFieldName)
+		return fmt.Errorf("could not mutate cloudprovider secret as %q field is missing", securityv1alpha1constants.DataKeyConfig)
+	}
+	workloadIdentityConfig, err := helper.WorkloadIdentityConfigFromBytes(rawConfig)
+	if err != nil {
+		return fmt.Errorf("could not decode workload identity config: %w", err)
+	}
+
+	// The token is written by the token requestor after the secret has been created.
+	token := newCloudProviderSecret.Data[securityv1alpha1constants.DataKeyToken]
+	if len(token) == 0 {
+		return nil
+	}
+
+	metalToken := string(token)
+	if workloadIdentityConfig.TokenExchange != nil {
+		// The exchanged token is written by the token exchange controller after the token has been requested.
+		metalToken = string(newCloudProviderSecret.Data[metal.ExchangedTokenFieldName])
+		if metalToken == "" {
+			return nil
+		}
 	}
 
+	authInfo := clientcmdv1.NamedAuthInfo{
+		Name: workloadIdentityAuthInfoName,
+		AuthInfo: clientcmdv1.AuthInfo{
+			Token: metalToken,
+		},
+	}
+	kubeconfig, err := e.kubeconfig(ctx, gctx, authInfo, workloadIdentityConfig.Namespace)
+	if err != nil {
+		return err
+	}
+
+	newCloudProviderSecret.Data[metal.NamespaceFieldName] = []byte(workloadIdentityConfig.Namespace)
+	newCloudProviderSecret.Data[metal.KubeConfigFieldName] = kubeconfig
+	return nil
+}
+
+// kubeconfig returns an encoded kubeconfig for the metal cluster of the shoot's region.
+func (e *ensurer) kubeconfig(ctx context.Context, gctx gcontext.GardenContext, authInfo clientcmdv1.NamedAuthInfo, namespace string) ([]byte, error) {
 	cluster, err := gctx.GetCluster(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to get cluster: %w", err)
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
 	}
 
 	cloudProfileConfig := &apismetal.CloudProfileConfig{}
 	raw, err := cluster.CloudProfile.Spec.ProviderConfig.MarshalJSON()
 	if err != nil {
-		return fmt.Errorf("could not decode cluster object's providerConfig: %w", err)
+		return nil, fmt.Errorf("could not decode cluster object's providerConfig: %w", err)
 	}
 	if _, _, err := e.decoder.Decode(raw, nil, cloudProfileConfig); err != nil {
-		return fmt.Errorf("could not decode cluster object's providerConfig: %w", err)
+		return nil, fmt.Errorf("could not decode cluster object's providerConfig: %w", err)
 	}
 
 	kubeconfig := &clientcmdv1.Config{
@@ -72,18 +191,13 @@ func (e *ensurer) EnsureCloudProviderSecret(ctx context.Context, gctx gcontext.G
 		Clusters: []clientcmdv1.NamedCluster{{
 			Name: cluster.Shoot.Spec.Region,
 		}},
-		AuthInfos: []clientcmdv1.NamedAuthInfo{{
-			Name: string(username),
-			AuthInfo: clientcmdv1.AuthInfo{
-				Token: string(token),
-			},
-		}},
+		AuthInfos: []clientcmdv1.NamedAuthInfo{authInfo},
 		Contexts: []clientcmdv1.NamedContext{{
 			Name: cluster.Shoot.Spec.Region,
 			Context: clientcmdv1.Context{
 				Cluster:   cluster.Shoot.Spec.Region,
-				AuthInfo:  string(username),
-				Namespace: string(namespace),
+				AuthInfo:  authInfo.Name,
+				Namespace: namespace,
 			},
 		}},
 	}
@@ -91,21 +205,58 @@ func (e *ensurer) EnsureCloudProviderSecret(ctx context.Context, gctx gcontext.G
 	var regionFound bool
 	for _, region := range cloudProfileConfig.RegionConfigs {
 		if region.Name == cluster.Shoot.Spec.Region {
-			kubeconfig.Clusters[0].Cluster.Server = region.Server
-			kubeconfig.Clusters[0].Cluster.CertificateAuthorityData = region.CertificateAuthorityData
+			kubeconfig.Clusters[0].Cluster = regionCluster(region, region.Server)
+			// The failover servers are added as further clusters and contexts in their order, which the clients of
+			// the extension fail over to if the server is unavailable.
+			for i, server := range region.FailoverServers {
+				name := fmt.Sprintf("%s-%d", cluster.Shoot.Spec.Region, i+1)
+				kubeconfig.Clusters = append(kubeconfig.Clusters, clientcmdv1.NamedCluster{
+					Name:    name,
+					Cluster: regionCluster(region, server),
+				})
+				kubeconfig.Contexts = append(kubeconfig.Contexts, clientcmdv1.NamedContext{
+					Name: name,
+					Context: clientcmdv1.Context{
+						Cluster:   name,
+						AuthInfo:  authInfo.Name,
+						Namespace: namespace,
+					},
+				})
+			}
 			regionFound = true
 			break
 		}
 	}
 	if !regionFound {
-		return fmt.Errorf("faild to find region %s in cloudprofile", cluster.Shoot.Spec.Region)
+		return nil, fmt.Errorf("faild to find region %s in cloudprofile", cluster.Shoot.Spec.Region)
 	}
 
 	raw, err = runtime.Encode(clientcmdlatest.Codec, kubeconfig)
 	if err != nil {
-		return fmt.Errorf("failed to encode kubeconfig: %w", err)
+		return nil, fmt.Errorf("failed to encode kubeconfig: %w", err)
 	}
+	return raw, nil
+}
 
-	newCloudProviderSecret.Data[metal.KubeConfigFieldName] = raw
-	return nil
+// regionCluster returns the kubeconfig cluster for the given server endpoint of the region.
+func regionCluster(region apismetal.RegionConfig, server string) clientcmdv1.Cluster {
+	return clientcmdv1.Cluster{
+		Server:                   server,
+		CertificateAuthorityData: regionCertificateAuthorityData(region),
+		ProxyURL:                 region.ProxyURL,
+		TLSServerName:            region.TLSServerName,
+	}
+}
+
+// regionCertificateAuthorityData returns the CA bundle of the region server including the additional CAs.
+func regionCertificateAuthorityData(region apismetal.RegionConfig) []byte {
+	if len(region.AdditionalCertificateAuthorityData) == 0 {
+		return region.CertificateAuthorityData
+	}
+
+	caData := append([]byte{}, bytes.TrimSpace(region.CertificateAuthorityData)...)
+	if len(caData) > 0 {
+		caData = append(caData, '\n')
+	}
+	return append(caData, region.AdditionalCertificateAuthorityData...)
 }
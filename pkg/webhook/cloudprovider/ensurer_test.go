@@ -5,6 +5,7 @@ package cloudprovider
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 
 	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
@@ -20,6 +21,9 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/clientcmd"
+	clientcmdv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/client-go/util/cert"
+	"sigs.k8s.io/yaml"
 
 	api "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
 )
@@ -156,6 +160,86 @@ var _ = Describe("Ensurer", func() {
 			Expect(config.AuthInfos["admin"].Token).To(Equal("bar"))
 		})
 
+		It("should add the proxy and TLS settings of the region to the kubeconfig", func() {
+			gctx := gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					CloudProfile: &gardencorev1beta1.CloudProfile{
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							ProviderConfig: &runtime.RawExtension{
+								Object: &api.CloudProfileConfig{
+									RegionConfigs: []api.RegionConfig{{
+										Name:                               "foo",
+										Server:                             "https://localhost",
+										CertificateAuthorityData:           []byte("region-ca\n"),
+										ProxyURL:                           "http://proxy.local:3128",
+										TLSServerName:                      "metal-api.local",
+										AdditionalCertificateAuthorityData: []byte("egress-ca\n"),
+									}},
+								},
+							},
+						},
+					},
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Region: "foo",
+						},
+					},
+				},
+			)
+
+			Expect(ensurer.EnsureCloudProviderSecret(ctx, gctx, secret, nil)).To(Succeed())
+
+			config, err := clientcmd.Load(secret.Data["kubeconfig"])
+			Expect(err).NotTo(HaveOccurred())
+			cluster := config.Clusters[config.CurrentContext]
+			Expect(cluster.Server).To(Equal("https://localhost"))
+			Expect(cluster.ProxyURL).To(Equal("http://proxy.local:3128"))
+			Expect(cluster.TLSServerName).To(Equal("metal-api.local"))
+			Expect(string(cluster.CertificateAuthorityData)).To(Equal("region-ca\negress-ca\n"))
+		})
+
+		It("should add the failover servers of the region to the kubeconfig in their order", func() {
+			gctx := gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					CloudProfile: &gardencorev1beta1.CloudProfile{
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							ProviderConfig: &runtime.RawExtension{
+								Object: &api.CloudProfileConfig{
+									RegionConfigs: []api.RegionConfig{{
+										Name:                     "foo",
+										Server:                   "https://metal-a.local",
+										FailoverServers:          []string{"https://metal-b.local", "https://metal-c.local"},
+										CertificateAuthorityData: []byte("abcd1234"),
+									}},
+								},
+							},
+						},
+					},
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Region: "foo",
+						},
+					},
+				},
+			)
+
+			Expect(ensurer.EnsureCloudProviderSecret(ctx, gctx, secret, nil)).To(Succeed())
+
+			config := &clientcmdv1.Config{}
+			Expect(yaml.Unmarshal(secret.Data["kubeconfig"], config)).To(Succeed())
+			Expect(config.CurrentContext).To(Equal("foo"))
+			Expect(config.Clusters).To(HaveExactElements(
+				clientcmdv1.NamedCluster{Name: "foo", Cluster: clientcmdv1.Cluster{Server: "https://metal-a.local", CertificateAuthorityData: []byte("abcd1234")}},
+				clientcmdv1.NamedCluster{Name: "foo-1", Cluster: clientcmdv1.Cluster{Server: "https://metal-b.local", CertificateAuthorityData: []byte("abcd1234")}},
+				clientcmdv1.NamedCluster{Name: "foo-2", Cluster: clientcmdv1.Cluster{Server: "https://metal-c.local", CertificateAuthorityData: []byte("abcd1234")}},
+			))
+			Expect(config.Contexts).To(HaveExactElements(
+				clientcmdv1.NamedContext{Name: "foo", Context: clientcmdv1.Context{Cluster: "foo", AuthInfo: "admin", Namespace: "foo"}},
+				clientcmdv1.NamedContext{Name: "foo-1", Context: clientcmdv1.Context{Cluster: "foo-1", AuthInfo: "admin", Namespace: "foo"}},
+				clientcmdv1.NamedContext{Name: "foo-2", Context: clientcmdv1.Context{Cluster: "foo-2", AuthInfo: "admin", Namespace: "foo"}},
+			))
+		})
+
 		It("should fail if the cloudprovider secret has no token", func() {
 			err := ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, secretWithoutToken, nil)
 			Expect(err).To(HaveOccurred())
@@ -170,5 +254,125 @@ var _ = Describe("Ensurer", func() {
 			err := ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, secretWithoutUsername, nil)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should fail if the cloudprovider secret contains mixed credentials", func() {
+			secret.Data["tls.crt"] = []byte("foo")
+
+			err := ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, secret, nil)
+			Expect(err).To(MatchError(ContainSubstring("mixed credentials")))
+		})
+
+		It("should add a kubeconfig with a client certificate to the cloudprovider secret", func() {
+			clientCertificate, clientKey, err := cert.GenerateSelfSignedCertKey("metal-user", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			secret.Data = map[string][]byte{
+				"namespace": []byte("foo"),
+				"tls.crt":   clientCertificate,
+				"tls.key":   clientKey,
+			}
+
+			Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, secret, nil)).To(Succeed())
+
+			config, err := clientcmd.Load(secret.Data["kubeconfig"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Contexts[config.CurrentContext].AuthInfo).To(Equal("client-certificate"))
+			Expect(config.AuthInfos["client-certificate"].ClientCertificateData).To(Equal(clientCertificate))
+			Expect(config.AuthInfos["client-certificate"].ClientKeyData).To(Equal(clientKey))
+			Expect(config.AuthInfos["client-certificate"].Token).To(BeEmpty())
+		})
+
+		It("should add a kubeconfig with OIDC credentials to the cloudprovider secret", func() {
+			caData, _, err := cert.GenerateSelfSignedCertKey("issuer.example.com", nil, nil)
+			Expect(err).NotTo(HaveOccurred())
+			secret.Data = map[string][]byte{
+				"namespace":                      []byte("foo"),
+				"username":                       []byte("metal-user"),
+				"idp-issuer-url":                 []byte("https://issuer.example.com"),
+				"client-id":                      []byte("metal"),
+				"refresh-token":                  []byte("refresh"),
+				"idp-certificate-authority-data": caData,
+			}
+
+			Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, secret, nil)).To(Succeed())
+
+			config, err := clientcmd.Load(secret.Data["kubeconfig"])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Contexts[config.CurrentContext].AuthInfo).To(Equal("metal-user"))
+			Expect(config.AuthInfos["metal-user"].AuthProvider).NotTo(BeNil())
+			Expect(config.AuthInfos["metal-user"].AuthProvider.Name).To(Equal("oidc"))
+			Expect(config.AuthInfos["metal-user"].AuthProvider.Config).To(Equal(map[string]string{
+				"idp-issuer-url":                 "https://issuer.example.com",
+				"client-id":                      "metal",
+				"refresh-token":                  "refresh",
+				"idp-certificate-authority-data": base64.StdEncoding.EncodeToString(caData),
+			}))
+		})
+
+		Context("WorkloadIdentity", func() {
+			var workloadIdentitySecret *corev1.Secret
+
+			BeforeEach(func() {
+				workloadIdentitySecret = &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "cloudprovider",
+						Labels: map[string]string{
+							"security.gardener.cloud/purpose": "workload-identity-token-requestor",
+						},
+					},
+					Data: map[string][]byte{
+						"config": []byte(`{"apiVersion":"ironcore-metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkloadIdentityConfig","namespace":"foo"}`),
+					},
+				}
+			})
+
+			It("should not add a kubeconfig as long as the token has not been requested", func() {
+				Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, workloadIdentitySecret, nil)).To(Succeed())
+
+				Expect(workloadIdentitySecret.Data).NotTo(HaveKey("kubeconfig"))
+			})
+
+			It("should fail if the workload identity config is missing", func() {
+				delete(workloadIdentitySecret.Data, "config")
+
+				Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, workloadIdentitySecret, nil)).NotTo(Succeed())
+			})
+
+			It("should add a kubeconfig with the workload identity token", func() {
+				workloadIdentitySecret.Data["token"] = []byte("workload-identity-token")
+
+				Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, workloadIdentitySecret, nil)).To(Succeed())
+
+				Expect(workloadIdentitySecret.Data).To(HaveKeyWithValue("namespace", []byte("foo")))
+				config, err := clientcmd.Load(workloadIdentitySecret.Data["kubeconfig"])
+				Expect(err).NotTo(HaveOccurred())
+				Expect(config.Clusters[config.CurrentContext].Server).To(Equal("https://localhost"))
+				Expect(config.Contexts[config.CurrentContext].Namespace).To(Equal("foo"))
+				Expect(config.AuthInfos["workload-identity"].Token).To(Equal("workload-identity-token"))
+			})
+
+			Context("token exchange", func() {
+				BeforeEach(func() {
+					workloadIdentitySecret.Data["config"] = []byte(`{"apiVersion":"ironcore-metal.provider.extensions.gardener.cloud/v1alpha1","kind":"WorkloadIdentityConfig","namespace":"foo","tokenExchange":{"url":"https://sts.example.com/token"}}`)
+					workloadIdentitySecret.Data["token"] = []byte("workload-identity-token")
+				})
+
+				It("should not add a kubeconfig as long as the token has not been exchanged", func() {
+					Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, workloadIdentitySecret, nil)).To(Succeed())
+
+					Expect(workloadIdentitySecret.Data).NotTo(HaveKey("kubeconfig"))
+				})
+
+				It("should add a kubeconfig with the exchanged token", func() {
+					workloadIdentitySecret.Data["exchanged-token"] = []byte("metal-token")
+
+					Expect(ensurer.EnsureCloudProviderSecret(ctx, eContextK8s, workloadIdentitySecret, nil)).To(Succeed())
+
+					kubeconfig, err := clientcmd.Load(workloadIdentitySecret.Data["kubeconfig"])
+					Expect(err).NotTo(HaveOccurred())
+					Expect(kubeconfig.AuthInfos["workload-identity"].Token).To(Equal("metal-token"))
+				})
+			})
+		})
 	})
 })
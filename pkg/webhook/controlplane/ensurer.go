@@ -8,13 +8,17 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/Masterminds/semver/v3"
 	"github.com/coreos/go-systemd/v22/unit"
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
 	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
 	extensionscontextwebhook "github.com/gardener/gardener/extensions/pkg/webhook/context"
 	"github.com/gardener/gardener/extensions/pkg/webhook/controlplane/genericmutator"
 	"github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	v1beta1helper "github.com/gardener/gardener/pkg/apis/core/v1beta1/helper"
 	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	"github.com/gardener/gardener/pkg/component/nodemanagement/machinecontrollermanager"
 	"github.com/go-logr/logr"
@@ -24,13 +28,73 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	vpaautoscalingv1 "k8s.io/autoscaler/vertical-pod-autoscaler/pkg/apis/autoscaling.k8s.io/v1"
 	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/imagevector"
 	metalapi "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
 	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	metalhelper "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
 )
 
+const (
+	hostnameUnitName            = "metal-hostname.service"
+	hostnameScriptPath          = "/opt/bin/metal-hostname.sh"
+	hostnameEnvironmentFilePath = "/var/lib/metal/hostname.env"
+	hostnameEnvironmentVariable = "METAL_NODE_NAME"
+
+	// hostnameSourceFQDN names the node after the fully qualified hostname of the machine.
+	hostnameSourceFQDN = "fqdn"
+	// hostnameSourceFile names the node after /etc/hostname, which is written according to the NodeNamePolicy.
+	hostnameSourceFile = "hostname-file"
+
+	// defaultIPv6NodeCIDRMask is the mask of the IPv6 node CIDRs if the metal load balancer does not configure one.
+	defaultIPv6NodeCIDRMask = 64
+
+	// expanderPriority is the cluster-autoscaler expander selecting worker pools by their configured priorities.
+	expanderPriority = "priority"
+)
+
+// hostnameScript configures the hostname of the machine and exposes it as node name to the kubelet. For worker
+// pools with a NodeNamePolicy, the name is taken from /etc/hostname, which the machine-controller-manager provider
+// writes into the ignition of every machine according to the policy. Otherwise, or if /etc/hostname is empty, the
+// fully qualified hostname of the machine is used.
+var hostnameScript = `#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+node_name=""
+if [[ "${1:-}" == "` + hostnameSourceFile + `" && -f /etc/hostname ]]; then
+  node_name="$(tr -d '[:space:]' < /etc/hostname)"
+  if [[ -z "${node_name}" ]]; then
+    echo "/etc/hostname is empty, falling back to the fully qualified hostname" >&2
+  fi
+fi
+if [[ -z "${node_name}" ]]; then
+  node_name="$(hostname -f 2>/dev/null || hostname)"
+fi
+
+hostnamectl set-hostname "${node_name}"
+
+mkdir -p "$(dirname "` + hostnameEnvironmentFilePath + `")"
+echo "` + hostnameEnvironmentVariable + `=${node_name}" > "` + hostnameEnvironmentFilePath + `"
+`
+
+func hostnameUnitContent(source string) string {
+	return `[Unit]
+Description=Configure the hostname and node name of the machine
+Before=kubelet.service
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=` + hostnameScriptPath + ` ` + source + `
+[Install]
+WantedBy=multi-user.target
+`
+}
+
 // NewEnsurer creates a new controlplane ensurer.
 func NewEnsurer(logger logr.Logger, scheme *runtime.Scheme) genericmutator.Ensurer {
 	return &ensurer{
@@ -59,12 +123,9 @@ func (e *ensurer) EnsureMachineControllerManagerDeployment(ctx context.Context,
 		return fmt.Errorf("failed to get cluster: %w", err)
 	}
 
-	cpConfig := &metalapi.ControlPlaneConfig{}
-	if cluster.Shoot != nil && cluster.Shoot.Spec.Provider.ControlPlaneConfig != nil {
-		cp := cluster.Shoot.Spec.Provider.ControlPlaneConfig
-		if _, _, err := e.decoder.Decode(cp.Raw, nil, cpConfig); err != nil {
-			return fmt.Errorf("could not decode providerConfig of controlplane for cluster %s: %w", client.ObjectKeyFromObject(cluster.Shoot), err)
-		}
+	cpConfig, err := e.controlPlaneConfig(cluster)
+	if err != nil {
+		return err
 	}
 
 	template := &newObj.Spec.Template
@@ -75,13 +136,25 @@ func (e *ensurer) EnsureMachineControllerManagerDeployment(ctx context.Context,
 		template.Labels = extensionswebhook.EnsureAnnotationOrLabel(template.Labels, metal.AllowEgressToIstioIngressLabel, "allowed")
 	}
 
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	metalAPIEgress, err := metalhelper.FindMetalAPIEgress(cloudProfileConfig, cluster.Shoot.Spec.Region)
+	if err != nil {
+		return err
+	}
+	if metalAPIEgress != nil {
+		template.Labels = extensionswebhook.EnsureAnnotationOrLabel(template.Labels, metal.AllowEgressToMetalAPILabel, "allowed")
+	}
+
 	ps.Containers = extensionswebhook.EnsureContainerWithName(
 		newObj.Spec.Template.Spec.Containers,
 		machinecontrollermanager.ProviderSidecarContainer(cluster.Shoot, newObj.Namespace, metal.ProviderName, image.String()),
 	)
 
 	if c := extensionswebhook.ContainerWithName(ps.Containers, metal.MachineControllerManagerProviderIroncoreImageName); c != nil {
-		ensureMCMCommandLineArgs(c, cpConfig)
+		ensureMCMCommandLineArgs(e.logger, c, cpConfig)
 		c.VolumeMounts = extensionswebhook.EnsureVolumeMountWithName(c.VolumeMounts, corev1.VolumeMount{
 			Name:      "cloudprovider",
 			MountPath: "/etc/metal",
@@ -101,6 +174,17 @@ func (e *ensurer) EnsureMachineControllerManagerDeployment(ctx context.Context,
 	return nil
 }
 
+func (e *ensurer) controlPlaneConfig(cluster *extensionscontroller.Cluster) (*metalapi.ControlPlaneConfig, error) {
+	cpConfig := &metalapi.ControlPlaneConfig{}
+	if cluster.Shoot != nil && cluster.Shoot.Spec.Provider.ControlPlaneConfig != nil {
+		cp := cluster.Shoot.Spec.Provider.ControlPlaneConfig
+		if _, _, err := e.decoder.Decode(cp.Raw, nil, cpConfig); err != nil {
+			return nil, fmt.Errorf("could not decode providerConfig of controlplane for cluster %s: %w", client.ObjectKeyFromObject(cluster.Shoot), err)
+		}
+	}
+	return cpConfig, nil
+}
+
 // EnsureMachineControllerManagerVPA ensures that the machine-controller-manager VPA conforms to the provider requirements.
 func (e *ensurer) EnsureMachineControllerManagerVPA(_ context.Context, _ extensionscontextwebhook.GardenContext, newObj, _ *vpaautoscalingv1.VerticalPodAutoscaler) error {
 	if newObj.Spec.ResourcePolicy == nil {
@@ -127,7 +211,7 @@ func (e *ensurer) EnsureKubeAPIServerDeployment(_ context.Context, _ extensionsc
 }
 
 // EnsureKubeControllerManagerDeployment ensures that the kube-controller-manager deployment conforms to the provider requirements.
-func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, gctx extensionscontextwebhook.GardenContext, new, _ *appsv1.Deployment) error {
+func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, gctx extensionscontextwebhook.GardenContext, new, old *appsv1.Deployment) error {
 	template := &new.Spec.Template
 	ps := &template.Spec
 
@@ -136,13 +220,40 @@ func (e *ensurer) EnsureKubeControllerManagerDeployment(ctx context.Context, gct
 		return err
 	}
 
-	allocateNodeCIDRs := true
-	if networkingConfig := cluster.Shoot.Spec.Networking; networkingConfig != nil && slices.Contains(networkingConfig.IPFamilies, v1beta1.IPFamilyIPv6) {
+	cpConfig, err := e.controlPlaneConfig(cluster)
+	if err != nil {
+		return err
+	}
+
+	var hasIPv4, hasIPv6 bool
+	if networkingConfig := cluster.Shoot.Spec.Networking; networkingConfig != nil {
+		hasIPv4 = len(networkingConfig.IPFamilies) == 0 || slices.Contains(networkingConfig.IPFamilies, v1beta1.IPFamilyIPv4)
+		hasIPv6 = slices.Contains(networkingConfig.IPFamilies, v1beta1.IPFamilyIPv6)
+	}
+
+	// Without the metal load balancer, the node CIDRs of IPv6 shoots are left to the IPAM of the CNI. With the metal
+	// load balancer, the metal-load-balancer-controller-manager allocates them if configured to do so.
+	allocateNodeCIDRs := !hasIPv6
+	ipv6NodeCIDRMask := int32(defaultIPv6NodeCIDRMask)
+	if cpConfig.LoadBalancerConfig != nil && cpConfig.LoadBalancerConfig.MetalLoadBalancerConfig != nil {
+		allocateNodeCIDRs = !cpConfig.LoadBalancerConfig.MetalLoadBalancerConfig.AllocateNodeCIDRs
+		if mask := cpConfig.LoadBalancerConfig.MetalLoadBalancerConfig.NodeCIDRMask; mask > 0 {
+			ipv6NodeCIDRMask = mask
+		}
+	}
+
+	// Node CIDRs of existing IPv6 shoots were left to the IPAM of the CNI so far. Allocating them now would assign node
+	// CIDRs which do not match the addresses of the running pods, so the allocation is only enabled for new control
+	// planes or if the kube-controller-manager already allocates them.
+	if allocateNodeCIDRs && hasIPv6 && old != nil && !kubeControllerManagerAllocatesNodeCIDRs(old) {
 		allocateNodeCIDRs = false
 	}
 
 	if c := extensionswebhook.ContainerWithName(ps.Containers, "kube-controller-manager"); c != nil {
 		ensureKubeControllerManagerCommandLineArgs(c, allocateNodeCIDRs)
+		if allocateNodeCIDRs && hasIPv6 {
+			ensureKubeControllerManagerIPv6NodeCIDRMask(c, hasIPv4, ipv6NodeCIDRMask)
+		}
 	}
 
 	return nil
@@ -154,11 +265,43 @@ func (e *ensurer) EnsureKubeSchedulerDeployment(_ context.Context, _ extensionsc
 }
 
 // EnsureClusterAutoscalerDeployment ensures that the cluster-autoscaler deployment conforms to the provider requirements.
-func (e *ensurer) EnsureClusterAutoscalerDeployment(_ context.Context, _ extensionscontextwebhook.GardenContext, _, _ *appsv1.Deployment) error {
+func (e *ensurer) EnsureClusterAutoscalerDeployment(ctx context.Context, gctx extensionscontextwebhook.GardenContext, new, _ *appsv1.Deployment) error {
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return err
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+
+	// The priority expander configuration is deployed by the control plane shoot chart.
+	if metalhelper.WorkerPoolPriorities(cloudProfileConfig, cluster.Shoot.Spec.Provider.Workers) == nil {
+		return nil
+	}
+
+	if c := extensionswebhook.ContainerWithName(new.Spec.Template.Spec.Containers, v1beta1constants.DeploymentNameClusterAutoscaler); c != nil {
+		ensureClusterAutoscalerPriorityExpander(c)
+	}
 	return nil
 }
 
-func ensureMCMCommandLineArgs(c *corev1.Container, cp *metalapi.ControlPlaneConfig) {
+// ensureClusterAutoscalerPriorityExpander prepends the priority expander to the expanders of the cluster-autoscaler,
+// so that the expanders configured in the Shoot decide between worker pools of the same priority.
+func ensureClusterAutoscalerPriorityExpander(c *corev1.Container) {
+	var expanders []string
+	if i := extensionswebhook.StringWithPrefixIndex(c.Command, "--expander="); i >= 0 {
+		expanders = strings.Split(strings.TrimPrefix(c.Command[i], "--expander="), ",")
+	}
+	if slices.Contains(expanders, expanderPriority) {
+		return
+	}
+	expanders = append([]string{expanderPriority}, expanders...)
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--expander=", strings.Join(expanders, ","))
+}
+
+func ensureMCMCommandLineArgs(log logr.Logger, c *corev1.Container, cp *metalapi.ControlPlaneConfig) {
 	c.Args = extensionswebhook.EnsureStringWithPrefix(c.Args, "--metal-kubeconfig=", "/etc/metal/kubeconfig")
 	if cp.NodeNamePolicy == "" {
 		return
@@ -166,7 +309,27 @@ func ensureMCMCommandLineArgs(c *corev1.Container, cp *metalapi.ControlPlaneConf
 	switch cp.NodeNamePolicy {
 	case metalapi.NodeNamePolicyBMCName, metalapi.NodeNamePolicyServerName, metalapi.NodeNamePolicyServerClaimName:
 		c.Args = extensionswebhook.EnsureStringWithPrefix(c.Args, "--node-name-policy=", string(cp.NodeNamePolicy))
+	default:
+		// unsupported policies are rejected when the Shoot is admitted, keep the default of the provider
+		log.Info("Ignoring unsupported node name policy", "nodeNamePolicy", cp.NodeNamePolicy)
+	}
+}
+
+// kubeControllerManagerAllocatesNodeCIDRs returns whether the kube-controller-manager of the given deployment
+// allocates node CIDRs.
+func kubeControllerManagerAllocatesNodeCIDRs(dep *appsv1.Deployment) bool {
+	c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+	return c != nil && (slices.Contains(c.Command, "--allocate-node-cidrs") || slices.Contains(c.Command, "--allocate-node-cidrs=true"))
+}
+
+// ensureKubeControllerManagerIPv6NodeCIDRMask sets the mask of the IPv6 node CIDRs, which is only configurable
+// for IPv4 in the Shoot. Dual-stack clusters require a mask per IP family.
+func ensureKubeControllerManagerIPv6NodeCIDRMask(c *corev1.Container, dualStack bool, mask int32) {
+	if dualStack {
+		c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--node-cidr-mask-size-ipv6=", strconv.Itoa(int(mask)))
+		return
 	}
+	c.Command = extensionswebhook.EnsureStringWithPrefix(c.Command, "--node-cidr-mask-size=", strconv.Itoa(int(mask)))
 }
 
 func ensureKubeAPIServerCommandLineArgs(c *corev1.Container) {
@@ -185,16 +348,36 @@ func ensureKubeControllerManagerCommandLineArgs(c *corev1.Container, allocateNod
 
 // EnsureKubeletServiceUnitOptions ensures that the kubelet.service unit options conform to the provider requirements.
 func (e *ensurer) EnsureKubeletServiceUnitOptions(_ context.Context, _ extensionscontextwebhook.GardenContext, _ *semver.Version, new, _ []*unit.UnitOption) ([]*unit.UnitOption, error) {
+	kubeletConfigPath := defaultKubeletConfigPath
 	if opt := extensionswebhook.UnitOptionWithSectionAndName(new, "Service", "ExecStart"); opt != nil {
 		command := extensionswebhook.DeserializeCommandLine(opt.Value)
 		command = ensureKubeletCommandLineArgs(command)
 		opt.Value = extensionswebhook.SerializeCommandLine(command, 1, " \\\n    ")
+		if i := slices.IndexFunc(command, func(arg string) bool { return strings.HasPrefix(arg, "--config=") }); i >= 0 {
+			kubeletConfigPath = strings.TrimPrefix(command[i], "--config=")
+		}
 	}
 
+	new = extensionswebhook.EnsureUnitOption(new, &unit.UnitOption{
+		Section: "Unit",
+		Name:    "Wants",
+		Value:   hostnameUnitName,
+	})
+	new = extensionswebhook.EnsureUnitOption(new, &unit.UnitOption{
+		Section: "Unit",
+		Name:    "After",
+		Value:   hostnameUnitName,
+	})
+	new = extensionswebhook.EnsureUnitOption(new, &unit.UnitOption{
+		Section: "Service",
+		Name:    "EnvironmentFile",
+		Value:   "-" + hostnameEnvironmentFilePath,
+	})
+	// failures are ignored as the kubelet reports a stale CPU manager checkpoint itself
 	new = extensionswebhook.EnsureUnitOption(new, &unit.UnitOption{
 		Section: "Service",
 		Name:    "ExecStartPre",
-		Value:   `/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'`,
+		Value:   "-" + cpuManagerStateScriptPath + " " + kubeletConfigPath,
 	})
 
 	return new, nil
@@ -202,11 +385,53 @@ func (e *ensurer) EnsureKubeletServiceUnitOptions(_ context.Context, _ extension
 
 func ensureKubeletCommandLineArgs(command []string) []string {
 	command = extensionswebhook.EnsureStringWithPrefix(command, "--cloud-provider=", "external")
+	// the kubelet falls back to the hostname of the machine if the variable is empty
+	command = extensionswebhook.EnsureStringWithPrefix(command, "--hostname-override=", "${"+hostnameEnvironmentVariable+"}")
 	return command
 }
 
 // EnsureKubeletConfiguration ensures that the kubelet configuration conforms to the provider requirements.
-func (e *ensurer) EnsureKubeletConfiguration(_ context.Context, _ extensionscontextwebhook.GardenContext, _ *semver.Version, _, _ *kubeletconfigv1beta1.KubeletConfiguration) error {
+// If the machine type of the worker pool declares its capacity, the reserved resources as well as the CPU and
+// topology manager policies are derived from it, unless they are configured in the Shoot.
+func (e *ensurer) EnsureKubeletConfiguration(ctx context.Context, gctx extensionscontextwebhook.GardenContext, _ *semver.Version, new, _ *kubeletconfigv1beta1.KubeletConfiguration) error {
+	if _, ok := workerPoolNameFromContext(ctx); !ok {
+		return nil
+	}
+
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get cluster: %w", err)
+	}
+	worker := workerPoolFromCluster(ctx, cluster)
+	if worker == nil {
+		return nil
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return err
+	}
+	capacity := metalhelper.FindMachineTypeCapacity(cloudProfileConfig, worker.Machine.Type)
+	if capacity == nil {
+		return nil
+	}
+
+	kubeletConfig := v1beta1helper.CalculateEffectiveKubeletConfiguration(cluster.Shoot.Spec.Kubernetes.Kubelet, worker.Kubernetes)
+	if kubeletConfig == nil {
+		kubeletConfig = &v1beta1.KubeletConfig{}
+	}
+
+	if kubeletConfig.KubeReserved == nil {
+		new.KubeReserved = ensureReserved(new.KubeReserved, kubeReservedForCapacity(capacity))
+	}
+	if kubeletConfig.SystemReserved == nil {
+		new.SystemReserved = ensureReserved(new.SystemReserved, systemReservedForCapacity(capacity))
+	}
+	if kubeletConfig.CPUManagerPolicy == nil {
+		new.CPUManagerPolicy = cpuManagerPolicyStatic
+	}
+	ensureTopologyManagerDefaults(new)
+
 	return nil
 }
 
@@ -216,11 +441,125 @@ func (e *ensurer) EnsureKubernetesGeneralConfiguration(_ context.Context, _ exte
 }
 
 // EnsureAdditionalUnits ensures that additional required system units are added.
-func (e *ensurer) EnsureAdditionalUnits(_ context.Context, _ extensionscontextwebhook.GardenContext, _, _ *[]extensionsv1alpha1.Unit) error {
+func (e *ensurer) EnsureAdditionalUnits(ctx context.Context, gctx extensionscontextwebhook.GardenContext, new, _ *[]extensionsv1alpha1.Unit) error {
+	policy, err := e.nodeNamePolicy(ctx, gctx)
+	if err != nil {
+		return err
+	}
+	source := hostnameSourceFQDN
+	if policy != "" {
+		source = hostnameSourceFile
+	}
+	*new = extensionswebhook.EnsureUnitWithName(*new, extensionsv1alpha1.Unit{
+		Name:      hostnameUnitName,
+		Command:   ptr.To(extensionsv1alpha1.CommandStart),
+		Enable:    ptr.To(true),
+		Content:   ptr.To(hostnameUnitContent(source)),
+		FilePaths: []string{hostnameScriptPath},
+	})
+
+	node, err := e.regionNodeConfig(ctx, gctx)
+	if err != nil {
+		return err
+	}
+	if node != nil {
+		machineImage, err := machineImageName(ctx, gctx)
+		if err != nil {
+			return err
+		}
+		*new = ensureRegionNodeUnits(*new, node, machineImage)
+	}
+
+	volumeType, err := e.localVolumeType(ctx, gctx)
+	if err != nil {
+		return err
+	}
+	if volumeType != "" {
+		*new = ensureLocalVolumesUnit(*new, volumeType)
+	}
 	return nil
 }
 
 // EnsureAdditionalFiles ensures that additional required system files are added.
-func (e *ensurer) EnsureAdditionalFiles(_ context.Context, _ extensionscontextwebhook.GardenContext, _, _ *[]extensionsv1alpha1.File) error {
+func (e *ensurer) EnsureAdditionalFiles(ctx context.Context, gctx extensionscontextwebhook.GardenContext, new, _ *[]extensionsv1alpha1.File) error {
+	*new = extensionswebhook.EnsureFileWithPath(*new, extensionsv1alpha1.File{
+		Path:        hostnameScriptPath,
+		Permissions: ptr.To[uint32](0755),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Data: hostnameScript,
+			},
+		},
+	})
+	*new = extensionswebhook.EnsureFileWithPath(*new, extensionsv1alpha1.File{
+		Path:        cpuManagerStateScriptPath,
+		Permissions: ptr.To[uint32](0755),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Data: cpuManagerStateScript,
+			},
+		},
+	})
+
+	node, err := e.regionNodeConfig(ctx, gctx)
+	if err != nil {
+		return err
+	}
+	if node != nil {
+		*new = ensureRegionNodeFiles(*new, node)
+	}
+
+	volumeType, err := e.localVolumeType(ctx, gctx)
+	if err != nil {
+		return err
+	}
+	if volumeType != "" {
+		*new = ensureLocalVolumesFiles(*new)
+	}
 	return nil
 }
+
+// nodeNamePolicy returns the NodeNamePolicy of the worker pool whose OperatingSystemConfig is mutated, which
+// defaults to the NodeNamePolicy of the ControlPlaneConfig.
+func (e *ensurer) nodeNamePolicy(ctx context.Context, gctx extensionscontextwebhook.GardenContext) (metalapi.NodeNamePolicy, error) {
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster: %w", err)
+	}
+	if worker := workerPoolFromCluster(ctx, cluster); worker != nil && worker.ProviderConfig != nil {
+		workerConfig := &metalapi.WorkerConfig{}
+		if _, _, err := e.decoder.Decode(worker.ProviderConfig.Raw, nil, workerConfig); err != nil {
+			return "", fmt.Errorf("could not decode providerConfig of worker pool %s: %w", worker.Name, err)
+		}
+		if workerConfig.NodeNamePolicy != "" {
+			return workerConfig.NodeNamePolicy, nil
+		}
+	}
+
+	cpConfig, err := e.controlPlaneConfig(cluster)
+	if err != nil {
+		return "", err
+	}
+	return cpConfig.NodeNamePolicy, nil
+}
+
+// machineImageName returns the name of the machine image of the worker pool whose OperatingSystemConfig is mutated,
+// if any.
+func machineImageName(ctx context.Context, gctx extensionscontextwebhook.GardenContext) (string, error) {
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster: %w", err)
+	}
+	if worker := workerPoolFromCluster(ctx, cluster); worker != nil && worker.Machine.Image != nil {
+		return worker.Machine.Image.Name, nil
+	}
+	return "", nil
+}
+
+func (e *ensurer) regionNodeConfig(ctx context.Context, gctx extensionscontextwebhook.GardenContext) (*metalapi.RegionNodeConfig, error) {
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster: %w", err)
+	}
+	return regionNodeConfigFromCluster(cluster)
+}
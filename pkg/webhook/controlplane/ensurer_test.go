@@ -17,12 +17,14 @@ import (
 	"github.com/gardener/gardener/extensions/pkg/webhook/controlplane/genericmutator"
 	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
 	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
 	gardenerutils "github.com/gardener/gardener/pkg/utils/gardener"
 	imagevectorutils "github.com/gardener/gardener/pkg/utils/imagevector"
 	testutils "github.com/gardener/gardener/pkg/utils/test"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 	"go.uber.org/mock/gomock"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -30,6 +32,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
 	"k8s.io/utils/ptr"
 
 	apismetal "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
@@ -288,12 +291,90 @@ var _ = Describe("Ensurer", func() {
 
 			Expect(c.Command).To(ContainElement("--allocate-node-cidrs=false"))
 		})
+
+		Context("metal load balancer", func() {
+			newContext := func(metalLoadBalancerConfig *apismetal.MetalLoadBalancerConfig, ipFamilies ...gardencorev1beta1.IPFamily) gcontext.GardenContext {
+				cpConfigRaw, err := json.Marshal(&apismetal.ControlPlaneConfig{
+					TypeMeta: metav1.TypeMeta{
+						APIVersion: v1alpha1.SchemeGroupVersion.String(),
+						Kind:       "ControlPlaneConfig",
+					},
+					LoadBalancerConfig: &apismetal.LoadBalancerConfig{
+						MetalLoadBalancerConfig: metalLoadBalancerConfig,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				return gcontext.NewInternalGardenContext(
+					&extensionscontroller.Cluster{
+						Shoot: &gardencorev1beta1.Shoot{
+							Spec: gardencorev1beta1.ShootSpec{
+								Networking: &gardencorev1beta1.Networking{IPFamilies: ipFamilies},
+								Provider: gardencorev1beta1.Provider{
+									ControlPlaneConfig: &runtime.RawExtension{Raw: cpConfigRaw},
+								},
+							},
+						},
+					},
+				)
+			}
+
+			It("should not allocate node CIDRs if the metal load balancer allocates them", func() {
+				Expect(ensurer.EnsureKubeControllerManagerDeployment(ctx, newContext(&apismetal.MetalLoadBalancerConfig{AllocateNodeCIDRs: true}, gardencorev1beta1.IPFamilyIPv4), dep, nil)).To(Succeed())
+
+				c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				Expect(c.Command).To(ContainElement("--allocate-node-cidrs=false"))
+			})
+
+			It("should allocate node CIDRs of both IP families in dual-stack shoots", func() {
+				c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				c.Command = []string{"--node-cidr-mask-size-ipv4=24", "--node-cidr-mask-size-ipv6=64"}
+
+				Expect(ensurer.EnsureKubeControllerManagerDeployment(ctx, newContext(&apismetal.MetalLoadBalancerConfig{NodeCIDRMask: 80}, gardencorev1beta1.IPFamilyIPv4, gardencorev1beta1.IPFamilyIPv6), dep, nil)).To(Succeed())
+
+				c = extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				Expect(c.Command).To(ContainElements("--allocate-node-cidrs=true", "--node-cidr-mask-size-ipv4=24", "--node-cidr-mask-size-ipv6=80"))
+			})
+
+			It("should not start to allocate node CIDRs in existing IPv6 shoots", func() {
+				oldDep := dep.DeepCopy()
+				c := extensionswebhook.ContainerWithName(oldDep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				c.Command = []string{"--allocate-node-cidrs=false"}
+
+				Expect(ensurer.EnsureKubeControllerManagerDeployment(ctx, newContext(&apismetal.MetalLoadBalancerConfig{}, gardencorev1beta1.IPFamilyIPv6), dep, oldDep)).To(Succeed())
+
+				c = extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				Expect(c.Command).To(ContainElement("--allocate-node-cidrs=false"))
+				Expect(c.Command).NotTo(ContainElement(HavePrefix("--node-cidr-mask-size")))
+			})
+
+			It("should keep allocating node CIDRs in IPv6 shoots", func() {
+				oldDep := dep.DeepCopy()
+				c := extensionswebhook.ContainerWithName(oldDep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				c.Command = []string{"--allocate-node-cidrs=true"}
+
+				Expect(ensurer.EnsureKubeControllerManagerDeployment(ctx, newContext(&apismetal.MetalLoadBalancerConfig{}, gardencorev1beta1.IPFamilyIPv6), dep, oldDep)).To(Succeed())
+
+				c = extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				Expect(c.Command).To(ContainElements("--allocate-node-cidrs=true", "--node-cidr-mask-size=64"))
+			})
+
+			It("should use the IPv6 node CIDR mask in IPv6 shoots", func() {
+				c := extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				c.Command = []string{"--node-cidr-mask-size=24"}
+
+				Expect(ensurer.EnsureKubeControllerManagerDeployment(ctx, newContext(&apismetal.MetalLoadBalancerConfig{}, gardencorev1beta1.IPFamilyIPv6), dep, nil)).To(Succeed())
+
+				c = extensionswebhook.ContainerWithName(dep.Spec.Template.Spec.Containers, "kube-controller-manager")
+				Expect(c.Command).To(ContainElements("--allocate-node-cidrs=true", "--node-cidr-mask-size=64"))
+				Expect(c.Command).NotTo(ContainElement("--node-cidr-mask-size=24"))
+			})
+		})
 	})
 
 	Describe("#EnsureKubeletServiceUnitOptions", func() {
 		var (
-			oldUnitOptions        []*unit.UnitOption
-			hostnamectlUnitOption *unit.UnitOption
+			oldUnitOptions []*unit.UnitOption
 		)
 
 		BeforeEach(func() {
@@ -305,12 +386,6 @@ var _ = Describe("Ensurer", func() {
 	    --config=/var/lib/kubelet/config/kubelet`,
 				},
 			}
-
-			hostnamectlUnitOption = &unit.UnitOption{
-				Section: "Service",
-				Name:    "ExecStartPre",
-				Value:   `/bin/sh -c 'hostnamectl set-hostname $(hostname -f)'`,
-			}
 		})
 
 		It("should modify existing elements of kubelet.service unit options",
@@ -319,9 +394,28 @@ var _ = Describe("Ensurer", func() {
 					{
 						Section: "Service",
 						Name:    "ExecStart",
-						Value:   "/opt/bin/hyperkube kubelet \\\n    --config=/var/lib/kubelet/config/kubelet \\\n    --cloud-provider=external",
+						Value:   "/opt/bin/hyperkube kubelet \\\n    --config=/var/lib/kubelet/config/kubelet \\\n    --cloud-provider=external \\\n    --hostname-override=${METAL_NODE_NAME}",
+					},
+					{
+						Section: "Unit",
+						Name:    "Wants",
+						Value:   "metal-hostname.service",
+					},
+					{
+						Section: "Unit",
+						Name:    "After",
+						Value:   "metal-hostname.service",
+					},
+					{
+						Section: "Service",
+						Name:    "EnvironmentFile",
+						Value:   "-/var/lib/metal/hostname.env",
+					},
+					{
+						Section: "Service",
+						Name:    "ExecStartPre",
+						Value:   "-/opt/bin/metal-cpu-manager-state.sh /var/lib/kubelet/config/kubelet",
 					},
-					hostnamectlUnitOption,
 				}
 
 				opts, err := ensurer.EnsureKubeletServiceUnitOptions(ctx, dummyContext, semver.MustParse("1.23.0"), oldUnitOptions, nil)
@@ -331,6 +425,442 @@ var _ = Describe("Ensurer", func() {
 		)
 	})
 
+	Describe("#EnsureKubeletConfiguration", func() {
+		var (
+			kubeletConfig *kubeletconfigv1beta1.KubeletConfiguration
+			shoot         *gardencorev1beta1.Shoot
+			eContext      gcontext.GardenContext
+			poolCtx       context.Context
+		)
+
+		BeforeEach(func() {
+			cloudProfileConfig, err := json.Marshal(&v1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				MachineTypes: []v1alpha1.MachineType{
+					{
+						Name: "large",
+						Capacity: &v1alpha1.MachineTypeCapacity{
+							CPU:       resource.MustParse("64"),
+							Memory:    resource.MustParse("272Gi"),
+							HugePages: map[string]resource.Quantity{"1Gi": resource.MustParse("16Gi")},
+						},
+					},
+					{
+						Name: "small",
+					},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			shoot = &gardencorev1beta1.Shoot{
+				Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{
+						Workers: []gardencorev1beta1.Worker{
+							{Name: "pool", Machine: gardencorev1beta1.Machine{Type: "large"}},
+							{Name: "other", Machine: gardencorev1beta1.Machine{Type: "small"}},
+						},
+					},
+				},
+			}
+			eContext = gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					Shoot: shoot,
+					CloudProfile: &gardencorev1beta1.CloudProfile{
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+						},
+					},
+				},
+			)
+			poolCtx = controlplane.WithWorkerPoolName(ctx, "pool")
+
+			kubeletConfig = &kubeletconfigv1beta1.KubeletConfiguration{
+				CPUManagerPolicy: "none",
+				KubeReserved:     map[string]string{"cpu": "80m", "memory": "1Gi", "pid": "20k"},
+			}
+		})
+
+		It("should derive the reserved resources and policies from the machine type capacity", func() {
+			Expect(ensurer.EnsureKubeletConfiguration(poolCtx, eContext, semver.MustParse("1.31.0"), kubeletConfig, nil)).To(Succeed())
+			Expect(kubeletConfig.KubeReserved).To(Equal(map[string]string{"cpu": "230m", "memory": "12165Mi", "pid": "20k"}))
+			Expect(kubeletConfig.SystemReserved).To(Equal(map[string]string{"cpu": "320m", "memory": "2621Mi"}))
+			Expect(kubeletConfig.CPUManagerPolicy).To(Equal("static"))
+			Expect(kubeletConfig.TopologyManagerPolicy).To(Equal("best-effort"))
+		})
+
+		It("should keep the reserved resources and CPU manager policy configured in the shoot", func() {
+			shoot.Spec.Provider.Workers[0].Kubernetes = &gardencorev1beta1.WorkerKubernetes{
+				Kubelet: &gardencorev1beta1.KubeletConfig{
+					CPUManagerPolicy: ptr.To("none"),
+					KubeReserved:     &gardencorev1beta1.KubeletConfigReserved{CPU: ptr.To(resource.MustParse("80m"))},
+					SystemReserved:   &gardencorev1beta1.KubeletConfigReserved{CPU: ptr.To(resource.MustParse("100m"))},
+				},
+			}
+
+			Expect(ensurer.EnsureKubeletConfiguration(poolCtx, eContext, semver.MustParse("1.31.0"), kubeletConfig, nil)).To(Succeed())
+			Expect(kubeletConfig.KubeReserved).To(Equal(map[string]string{"cpu": "80m", "memory": "1Gi", "pid": "20k"}))
+			Expect(kubeletConfig.SystemReserved).To(BeNil())
+			Expect(kubeletConfig.CPUManagerPolicy).To(Equal("none"))
+		})
+
+		It("should keep an explicitly configured topology manager policy", func() {
+			kubeletConfig.TopologyManagerPolicy = "none"
+
+			Expect(ensurer.EnsureKubeletConfiguration(poolCtx, eContext, semver.MustParse("1.31.0"), kubeletConfig, nil)).To(Succeed())
+			Expect(kubeletConfig.TopologyManagerPolicy).To(Equal("none"))
+		})
+
+		It("should not modify the kubelet configuration of machine types without capacity", func() {
+			expected := kubeletConfig.DeepCopy()
+
+			Expect(ensurer.EnsureKubeletConfiguration(controlplane.WithWorkerPoolName(ctx, "other"), eContext, semver.MustParse("1.31.0"), kubeletConfig, nil)).To(Succeed())
+			Expect(kubeletConfig).To(Equal(expected))
+		})
+
+		It("should not modify the kubelet configuration without worker pool", func() {
+			expected := kubeletConfig.DeepCopy()
+
+			Expect(ensurer.EnsureKubeletConfiguration(ctx, eContext, semver.MustParse("1.31.0"), kubeletConfig, nil)).To(Succeed())
+			Expect(kubeletConfig).To(Equal(expected))
+		})
+	})
+
+	Describe("additional units and files", func() {
+		newContext := func(regionNode *v1alpha1.RegionNodeConfig) gcontext.GardenContext {
+			cloudProfileConfig, err := json.Marshal(&v1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				RegionConfigs: []v1alpha1.RegionConfig{
+					{Name: "foo", Node: regionNode},
+					{Name: "bar", Node: &v1alpha1.RegionNodeConfig{LLDP: true}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			return gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{Spec: gardencorev1beta1.ShootSpec{Region: "foo"}},
+					CloudProfile: &gardencorev1beta1.CloudProfile{
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+						},
+					},
+				},
+			)
+		}
+
+		Describe("#EnsureAdditionalUnits", func() {
+			It("should add the hostname unit", func() {
+				units := []extensionsv1alpha1.Unit{{Name: "foo.service"}}
+
+				Expect(ensurer.EnsureAdditionalUnits(ctx, newContext(nil), &units, nil)).To(Succeed())
+				Expect(units).To(HaveLen(2))
+				Expect(units[1].Name).To(Equal("metal-hostname.service"))
+				Expect(units[1].Command).To(Equal(ptr.To(extensionsv1alpha1.CommandStart)))
+				Expect(units[1].Enable).To(Equal(ptr.To(true)))
+				Expect(units[1].Content).To(PointTo(ContainSubstring("ExecStart=/opt/bin/metal-hostname.sh fqdn\n")))
+				Expect(units[1].FilePaths).To(ConsistOf("/opt/bin/metal-hostname.sh"))
+			})
+
+			It("should name the nodes after /etc/hostname if the shoot has a node name policy", func() {
+				gctx := newContext(nil)
+				cluster, err := gctx.GetCluster(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				cluster.Shoot.Spec.Provider.ControlPlaneConfig = &runtime.RawExtension{Raw: controlPlaneConfigRaw}
+				units := []extensionsv1alpha1.Unit{}
+
+				Expect(ensurer.EnsureAdditionalUnits(ctx, gctx, &units, nil)).To(Succeed())
+				Expect(units[0].Name).To(Equal("metal-hostname.service"))
+				Expect(units[0].Content).To(PointTo(ContainSubstring("ExecStart=/opt/bin/metal-hostname.sh hostname-file\n")))
+			})
+
+			It("should name the nodes after /etc/hostname if the worker pool has a node name policy", func() {
+				workerConfig, err := json.Marshal(&apismetal.WorkerConfig{NodeNamePolicy: apismetal.NodeNamePolicyBMCName})
+				Expect(err).NotTo(HaveOccurred())
+				gctx := newContext(nil)
+				cluster, err := gctx.GetCluster(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				cluster.Shoot.Spec.Provider.Workers = []gardencorev1beta1.Worker{
+					{Name: "pool", ProviderConfig: &runtime.RawExtension{Raw: workerConfig}},
+				}
+				units := []extensionsv1alpha1.Unit{}
+
+				Expect(ensurer.EnsureAdditionalUnits(controlplane.WithWorkerPoolName(ctx, "pool"), gctx, &units, nil)).To(Succeed())
+				Expect(units[0].Name).To(Equal("metal-hostname.service"))
+				Expect(units[0].Content).To(PointTo(ContainSubstring("ExecStart=/opt/bin/metal-hostname.sh hostname-file\n")))
+			})
+
+			It("should add the units for the node settings of the region", func() {
+				regionNode := &v1alpha1.RegionNodeConfig{
+					NTPServers: []string{"ntp.local"},
+					DNSServers: []string{"10.0.0.53"},
+					LLDP:       true,
+					CABundle:   "ca",
+				}
+				units := []extensionsv1alpha1.Unit{{Name: "updatecacerts.service", FilePaths: []string{"/var/lib/ca-certificates-local/ROOTcerts.crt"}}}
+
+				Expect(ensurer.EnsureAdditionalUnits(ctx, newContext(regionNode), &units, nil)).To(Succeed())
+				Expect(units).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":      Equal("updatecacerts.service"),
+						"FilePaths": ConsistOf("/var/lib/ca-certificates-local/ROOTcerts.crt", "/var/lib/ca-certificates-local/metal-region.crt", "/etc/ssl/certs/metal-region.pem", "/etc/pki/trust/anchors/metal-region.pem"),
+					}),
+					MatchFields(IgnoreExtras, Fields{"Name": Equal("metal-hostname.service")}),
+					Equal(extensionsv1alpha1.Unit{
+						Name:      "systemd-timesyncd.service",
+						Command:   ptr.To(extensionsv1alpha1.CommandRestart),
+						Enable:    ptr.To(true),
+						FilePaths: []string{"/etc/systemd/timesyncd.conf.d/metal.conf"},
+					}),
+					Equal(extensionsv1alpha1.Unit{
+						Name:      "systemd-resolved.service",
+						Command:   ptr.To(extensionsv1alpha1.CommandRestart),
+						Enable:    ptr.To(true),
+						FilePaths: []string{"/etc/systemd/resolved.conf.d/metal.conf"},
+					}),
+					Equal(extensionsv1alpha1.Unit{
+						Name:    "lldpd.service",
+						Command: ptr.To(extensionsv1alpha1.CommandStart),
+						Enable:  ptr.To(true),
+					}),
+				))
+			})
+
+			It("should not enable lldpd on machine images without it", func() {
+				gctx := newContext(&v1alpha1.RegionNodeConfig{LLDP: true})
+				cluster, err := gctx.GetCluster(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				cluster.Shoot.Spec.Provider.Workers = []gardencorev1beta1.Worker{
+					{Name: "pool", Machine: gardencorev1beta1.Machine{Image: &gardencorev1beta1.ShootMachineImage{Name: "flatcar"}}},
+				}
+				var units []extensionsv1alpha1.Unit
+
+				Expect(ensurer.EnsureAdditionalUnits(controlplane.WithWorkerPoolName(ctx, "pool"), gctx, &units, nil)).To(Succeed())
+				Expect(units).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"Name": Equal("metal-hostname.service")})))
+			})
+		})
+
+		Describe("#EnsureAdditionalFiles", func() {
+			It("should add the hostname script", func() {
+				var files []extensionsv1alpha1.File
+
+				Expect(ensurer.EnsureAdditionalFiles(ctx, newContext(nil), &files, nil)).To(Succeed())
+				Expect(files).To(HaveLen(2))
+				Expect(files[0].Path).To(Equal("/opt/bin/metal-hostname.sh"))
+				Expect(files[0].Permissions).To(Equal(ptr.To[uint32](0755)))
+				Expect(files[0].Content.Inline).NotTo(BeNil())
+				Expect(files[0].Content.Inline.Data).To(And(
+					ContainSubstring(`node_name="$(hostname -f 2>/dev/null || hostname)"`),
+					ContainSubstring(`hostnamectl set-hostname "${node_name}"`),
+					ContainSubstring(`echo "METAL_NODE_NAME=${node_name}" > "/var/lib/metal/hostname.env"`),
+				))
+			})
+
+			It("should add the script removing stale CPU manager checkpoints", func() {
+				var files []extensionsv1alpha1.File
+
+				Expect(ensurer.EnsureAdditionalFiles(ctx, newContext(nil), &files, nil)).To(Succeed())
+				file := extensionswebhook.FileWithPath(files, "/opt/bin/metal-cpu-manager-state.sh")
+				Expect(file).NotTo(BeNil())
+				Expect(file.Permissions).To(Equal(ptr.To[uint32](0755)))
+				Expect(file.Content.Inline.Data).To(And(
+					ContainSubstring(`state="/var/lib/kubelet/cpu_manager_state"`),
+					ContainSubstring(`rm -f "${state}"`),
+				))
+			})
+
+			It("should add the files for the node settings of the region", func() {
+				regionNode := &v1alpha1.RegionNodeConfig{
+					NTPServers: []string{"ntp1.local", "ntp2.local"},
+					DNSServers: []string{"10.0.0.53", "10.0.1.53"},
+					CABundle:   "ca",
+				}
+				var files []extensionsv1alpha1.File
+
+				Expect(ensurer.EnsureAdditionalFiles(ctx, newContext(regionNode), &files, nil)).To(Succeed())
+
+				fileContent := func(path string) string {
+					file := extensionswebhook.FileWithPath(files, path)
+					Expect(file).NotTo(BeNil(), path)
+					return file.Content.Inline.Data
+				}
+				Expect(files).To(HaveLen(7))
+				Expect(fileContent("/etc/systemd/timesyncd.conf.d/metal.conf")).To(Equal("[Time]\nNTP=ntp1.local ntp2.local\n"))
+				Expect(fileContent("/etc/systemd/resolved.conf.d/metal.conf")).To(Equal("[Resolve]\nDNS=10.0.0.53 10.0.1.53\n"))
+				Expect(fileContent("/var/lib/ca-certificates-local/metal-region.crt")).To(Equal("ca"))
+				Expect(fileContent("/etc/ssl/certs/metal-region.pem")).To(Equal("ca"))
+				Expect(fileContent("/etc/pki/trust/anchors/metal-region.pem")).To(Equal("ca"))
+			})
+		})
+	})
+
+	Describe("local volumes", func() {
+		var (
+			shoot    *gardencorev1beta1.Shoot
+			eContext gcontext.GardenContext
+			poolCtx  context.Context
+		)
+
+		BeforeEach(func() {
+			cloudProfileConfig, err := json.Marshal(&v1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				StorageClasses: v1alpha1.StorageClasses{
+					Default:    &v1alpha1.StorageClass{Name: "local-ssd", Type: "ssd"},
+					Additional: []v1alpha1.StorageClass{{Name: "local-nvme", Type: "nvme"}},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			controlPlaneConfig, err := json.Marshal(&apismetal.ControlPlaneConfig{
+				Storage: &apismetal.StorageConfig{StorageClasses: []string{"local-nvme"}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			shoot = &gardencorev1beta1.Shoot{
+				Spec: gardencorev1beta1.ShootSpec{
+					Provider: gardencorev1beta1.Provider{
+						ControlPlaneConfig: &runtime.RawExtension{Raw: controlPlaneConfig},
+						Workers: []gardencorev1beta1.Worker{
+							{Name: "pool", Volume: &gardencorev1beta1.Volume{Type: ptr.To("nvme")}},
+							{Name: "ssd", Volume: &gardencorev1beta1.Volume{Type: ptr.To("ssd")}},
+							{Name: "other"},
+						},
+					},
+				},
+			}
+			eContext = gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					Shoot: shoot,
+					CloudProfile: &gardencorev1beta1.CloudProfile{
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+						},
+					},
+				},
+			)
+			poolCtx = controlplane.WithWorkerPoolName(ctx, "pool")
+		})
+
+		It("should prepare the local volumes of worker pools whose volume type has a StorageClass", func() {
+			var (
+				units []extensionsv1alpha1.Unit
+				files []extensionsv1alpha1.File
+			)
+
+			Expect(ensurer.EnsureAdditionalUnits(poolCtx, eContext, &units, nil)).To(Succeed())
+			unit := extensionswebhook.UnitWithName(units, "metal-local-volumes.service")
+			Expect(unit).NotTo(BeNil())
+			Expect(unit.Command).To(Equal(ptr.To(extensionsv1alpha1.CommandStart)))
+			Expect(unit.Content).To(PointTo(And(
+				ContainSubstring("Before=kubelet.service"),
+				ContainSubstring("ExecStart=/opt/bin/metal-local-volumes.sh nvme"),
+			)))
+			Expect(unit.FilePaths).To(ConsistOf("/opt/bin/metal-local-volumes.sh"))
+
+			Expect(ensurer.EnsureAdditionalFiles(poolCtx, eContext, &files, nil)).To(Succeed())
+			file := extensionswebhook.FileWithPath(files, "/opt/bin/metal-local-volumes.sh")
+			Expect(file).NotTo(BeNil())
+			Expect(file.Permissions).To(Equal(ptr.To[uint32](0755)))
+			Expect(file.Content.Inline.Data).To(ContainSubstring(`host_dir="/mnt/disks/$1"`))
+		})
+
+		DescribeTable("should not prepare local volumes",
+			func(mutate func()) {
+				mutate()
+				var (
+					units []extensionsv1alpha1.Unit
+					files []extensionsv1alpha1.File
+				)
+
+				Expect(ensurer.EnsureAdditionalUnits(poolCtx, eContext, &units, nil)).To(Succeed())
+				Expect(extensionswebhook.UnitWithName(units, "metal-local-volumes.service")).To(BeNil())
+				Expect(ensurer.EnsureAdditionalFiles(poolCtx, eContext, &files, nil)).To(Succeed())
+				Expect(extensionswebhook.FileWithPath(files, "/opt/bin/metal-local-volumes.sh")).To(BeNil())
+			},
+			Entry("without worker pool", func() { poolCtx = ctx }),
+			Entry("for worker pools without volume", func() { poolCtx = controlplane.WithWorkerPoolName(ctx, "other") }),
+			Entry("for volume types whose StorageClass is not deployed", func() { poolCtx = controlplane.WithWorkerPoolName(ctx, "ssd") }),
+			Entry("without storage configuration", func() { shoot.Spec.Provider.ControlPlaneConfig = nil }),
+		)
+	})
+
+	Describe("#EnsureClusterAutoscalerDeployment", func() {
+		var (
+			dep        *appsv1.Deployment
+			newContext func(workers ...gardencorev1beta1.Worker) gcontext.GardenContext
+		)
+
+		BeforeEach(func() {
+			dep = &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: v1beta1constants.DeploymentNameClusterAutoscaler},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{
+								Name:    v1beta1constants.DeploymentNameClusterAutoscaler,
+								Command: []string{"./cluster-autoscaler", "--expander=least-waste"},
+							}},
+						},
+					},
+				},
+			}
+
+			cloudProfileConfig, err := json.Marshal(&v1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				MachineTypes: []v1alpha1.MachineType{
+					{Name: "cheap", Priority: ptr.To[int32](10)},
+					{Name: "expensive"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			newContext = func(workers ...gardencorev1beta1.Worker) gcontext.GardenContext {
+				return gcontext.NewInternalGardenContext(
+					&extensionscontroller.Cluster{
+						Shoot: &gardencorev1beta1.Shoot{
+							Spec: gardencorev1beta1.ShootSpec{
+								Provider: gardencorev1beta1.Provider{Workers: workers},
+							},
+						},
+						CloudProfile: &gardencorev1beta1.CloudProfile{
+							Spec: gardencorev1beta1.CloudProfileSpec{
+								ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+							},
+						},
+					},
+				)
+			}
+		})
+
+		It("should prepend the priority expander if a machine type of the workers has a priority", func(ctx SpecContext) {
+			gctx := newContext(gardencorev1beta1.Worker{Name: "pool", Machine: gardencorev1beta1.Machine{Type: "cheap"}})
+
+			Expect(ensurer.EnsureClusterAutoscalerDeployment(ctx, gctx, dep, nil)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(ConsistOf("./cluster-autoscaler", "--expander=priority,least-waste"))
+
+			By("keeping the expanders if the priority expander is already configured")
+			Expect(ensurer.EnsureClusterAutoscalerDeployment(ctx, gctx, dep, nil)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(ConsistOf("./cluster-autoscaler", "--expander=priority,least-waste"))
+		})
+
+		It("should not change the expanders if no machine type of the workers has a priority", func(ctx SpecContext) {
+			gctx := newContext(gardencorev1beta1.Worker{Name: "pool", Machine: gardencorev1beta1.Machine{Type: "expensive"}})
+
+			Expect(ensurer.EnsureClusterAutoscalerDeployment(ctx, gctx, dep, nil)).To(Succeed())
+			Expect(dep.Spec.Template.Spec.Containers[0].Command).To(ConsistOf("./cluster-autoscaler", "--expander=least-waste"))
+		})
+	})
+
 	Describe("#EnsureMachineControllerManagerDeployment", func() {
 		var (
 			deployment *appsv1.Deployment
@@ -349,6 +879,7 @@ var _ = Describe("Ensurer", func() {
 			Expect(deployment.Spec.Template.Spec.Containers).To(BeEmpty())
 			Expect(ensurer.EnsureMachineControllerManagerDeployment(ctx, eContextK8s, deployment, nil)).To(Succeed())
 			Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(metal.AllowEgressToIstioIngressLabel, "allowed"))
+			Expect(deployment.Spec.Template.Labels).NotTo(HaveKey(metal.AllowEgressToMetalAPILabel))
 			Expect(deployment.Spec.Template.Spec.Containers).To(ConsistOf(corev1.Container{
 				Name:            "machine-controller-manager-provider-ironcore-metal",
 				Image:           "foo:bar",
@@ -426,6 +957,64 @@ var _ = Describe("Ensurer", func() {
 				),
 			)
 		})
+
+		It("should allow egress to the metal API of the region", func() {
+			cloudProfileConfig, err := json.Marshal(&v1alpha1.CloudProfileConfig{
+				TypeMeta: metav1.TypeMeta{
+					APIVersion: v1alpha1.SchemeGroupVersion.String(),
+					Kind:       "CloudProfileConfig",
+				},
+				RegionConfigs: []v1alpha1.RegionConfig{{
+					Name:        "foo",
+					Server:      "https://metal.foo.local",
+					EgressCIDRs: []string{"10.0.0.0/24"},
+				}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			eContext := gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					CloudProfile: &gardencorev1beta1.CloudProfile{
+						Spec: gardencorev1beta1.CloudProfileSpec{
+							ProviderConfig: &runtime.RawExtension{Raw: cloudProfileConfig},
+						},
+					},
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Region: "foo",
+						},
+					},
+					Seed: &gardencorev1beta1.Seed{},
+				},
+			)
+
+			Expect(ensurer.EnsureMachineControllerManagerDeployment(ctx, eContext, deployment, nil)).To(Succeed())
+			Expect(deployment.Spec.Template.Labels).To(HaveKeyWithValue(metal.AllowEgressToMetalAPILabel, "allowed"))
+			Expect(deployment.Spec.Template.Labels).NotTo(HaveKey(metal.AllowEgressToIstioIngressLabel))
+		})
+
+		It("should ignore an unsupported node name policy", func() {
+			controlPlaneConfig.NodeNamePolicy = "Foo"
+			controlPlaneConfigRaw, err := json.Marshal(controlPlaneConfig)
+			Expect(err).NotTo(HaveOccurred())
+			eContext := gcontext.NewInternalGardenContext(
+				&extensionscontroller.Cluster{
+					Shoot: &gardencorev1beta1.Shoot{
+						Spec: gardencorev1beta1.ShootSpec{
+							Provider: gardencorev1beta1.Provider{
+								ControlPlaneConfig: &runtime.RawExtension{Raw: controlPlaneConfigRaw},
+							},
+						},
+					},
+					Seed: &gardencorev1beta1.Seed{},
+				},
+			)
+
+			Expect(ensurer.EnsureMachineControllerManagerDeployment(ctx, eContext, deployment, nil)).To(Succeed())
+			c := extensionswebhook.ContainerWithName(deployment.Spec.Template.Spec.Containers, "machine-controller-manager-provider-ironcore-metal")
+			Expect(c).NotTo(BeNil())
+			Expect(c.Args).To(ContainElement("--metal-kubeconfig=/etc/metal/kubeconfig"))
+			Expect(c.Args).NotTo(ContainElement(HavePrefix("--node-name-policy=")))
+		})
 	})
 })
 
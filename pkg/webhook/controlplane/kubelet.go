@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"k8s.io/apimachinery/pkg/api/resource"
+	kubeletconfigv1beta1 "k8s.io/kubelet/config/v1beta1"
+
+	metalapi "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+)
+
+const (
+	// cpuManagerPolicyStatic is the CPU manager policy granting exclusive CPUs to guaranteed pods with integer CPU
+	// requests.
+	cpuManagerPolicyStatic = "static"
+
+	cpuManagerStateScriptPath = "/opt/bin/metal-cpu-manager-state.sh"
+	cpuManagerStatePath       = "/var/lib/kubelet/cpu_manager_state"
+	// defaultKubeletConfigPath is the kubelet configuration file if the kubelet command line does not pass one.
+	defaultKubeletConfigPath = "/var/lib/kubelet/config/kubelet"
+
+	mebibyte = int64(1) << 20
+	gibibyte = int64(1) << 30
+)
+
+// reservationTier reserves a fraction of the capacity up to the given limit. The limit of the last tier is zero,
+// which means unlimited.
+type reservationTier struct {
+	limit    int64
+	fraction float64
+}
+
+// kubeReservedCPUTiers reserve 6% of the first core, 1% of the second core, 0.5% of the next two cores and 0.25% of
+// all further cores for the kubelet and the container runtime. The limits are in millicores.
+var kubeReservedCPUTiers = []reservationTier{
+	{limit: 1000, fraction: 0.06},
+	{limit: 2000, fraction: 0.01},
+	{limit: 4000, fraction: 0.005},
+	{fraction: 0.0025},
+}
+
+// kubeReservedMemoryTiers reserve 25% of the first 4Gi, 20% of the next 4Gi, 10% of the next 8Gi, 6% of the next
+// 112Gi and 2% of all further memory for the kubelet and the container runtime.
+var kubeReservedMemoryTiers = []reservationTier{
+	{limit: 4 * gibibyte, fraction: 0.25},
+	{limit: 8 * gibibyte, fraction: 0.2},
+	{limit: 16 * gibibyte, fraction: 0.1},
+	{limit: 128 * gibibyte, fraction: 0.06},
+	{fraction: 0.02},
+}
+
+// reserve returns the amount of the given capacity reserved by the given tiers.
+func reserve(capacity int64, tiers []reservationTier) int64 {
+	var (
+		reserved float64
+		lower    int64
+	)
+	for _, tier := range tiers {
+		upper := capacity
+		if tier.limit > 0 && tier.limit < capacity {
+			upper = tier.limit
+		}
+		if upper <= lower {
+			break
+		}
+		reserved += float64(upper-lower) * tier.fraction
+		lower = upper
+	}
+	return int64(reserved)
+}
+
+// allocatableMemory returns the memory of the given capacity which is not preallocated as huge pages.
+func allocatableMemory(capacity *metalapi.MachineTypeCapacity) int64 {
+	memory := capacity.Memory.Value()
+	for _, hugePages := range capacity.HugePages {
+		memory -= hugePages.Value()
+	}
+	return max(memory, 0)
+}
+
+// kubeReservedForCapacity returns the CPU and memory reserved for the kubelet and the container runtime on servers
+// of the given capacity.
+func kubeReservedForCapacity(capacity *metalapi.MachineTypeCapacity) map[string]string {
+	cpu := reserve(capacity.CPU.MilliValue(), kubeReservedCPUTiers)
+	memory := reserve(allocatableMemory(capacity), kubeReservedMemoryTiers) / mebibyte * mebibyte
+
+	return map[string]string{
+		"cpu":    resource.NewMilliQuantity(cpu, resource.DecimalSI).String(),
+		"memory": resource.NewQuantity(memory, resource.BinarySI).String(),
+	}
+}
+
+// systemReservedForCapacity returns the CPU and memory reserved for the system daemons on servers of the given
+// capacity: 0.5% of the CPU and 1% of the memory, but at least 100m CPU and 256Mi memory.
+func systemReservedForCapacity(capacity *metalapi.MachineTypeCapacity) map[string]string {
+	cpu := max(capacity.CPU.MilliValue()/200, 100)
+	memory := max(allocatableMemory(capacity)/100/mebibyte*mebibyte, 256*mebibyte)
+
+	return map[string]string{
+		"cpu":    resource.NewMilliQuantity(cpu, resource.DecimalSI).String(),
+		"memory": resource.NewQuantity(memory, resource.BinarySI).String(),
+	}
+}
+
+// ensureReserved sets the CPU and memory of the given reservation while keeping other reserved resources, e.g. PIDs.
+func ensureReserved(reserved map[string]string, values map[string]string) map[string]string {
+	if reserved == nil {
+		reserved = make(map[string]string, len(values))
+	}
+	for name, value := range values {
+		reserved[name] = value
+	}
+	return reserved
+}
+
+// ensureTopologyManagerDefaults aligns the CPU and huge page allocations of pods to NUMA nodes on a best-effort basis
+// unless a topology manager policy is already configured, including an explicit "none".
+func ensureTopologyManagerDefaults(config *kubeletconfigv1beta1.KubeletConfiguration) {
+	if config.TopologyManagerPolicy == "" {
+		config.TopologyManagerPolicy = kubeletconfigv1beta1.BestEffortTopologyManagerPolicy
+	}
+}
+
+// cpuManagerStateScript removes the checkpoint of the CPU manager if it was written for another policy than the one
+// configured in the given kubelet configuration file, as the kubelet refuses to start otherwise. This happens on
+// existing nodes when the CPU manager policy of their machine type changes.
+var cpuManagerStateScript = `#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+config="$1"
+state="` + cpuManagerStatePath + `"
+if [[ ! -f "${state}" || ! -f "${config}" ]]; then
+  exit 0
+fi
+
+policy="$(sed -n 's/^cpuManagerPolicy:[[:space:]]*//p' "${config}" | tr -d '"[:space:]')"
+policy="${policy:-none}"
+if ! grep -q "\"policyName\":\"${policy}\"" "${state}"; then
+  echo "Removing ${state} as the CPU manager policy changed to ${policy}" >&2
+  rm -f "${state}"
+fi
+`
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	gardencorev1beta1 "github.com/gardener/gardener/pkg/apis/core/v1beta1"
+	v1beta1constants "github.com/gardener/gardener/pkg/apis/core/v1beta1/constants"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type workerPoolNameKey struct{}
+
+// WithWorkerPoolName returns a copy of the given context carrying the name of the worker pool whose
+// OperatingSystemConfig is mutated.
+func WithWorkerPoolName(ctx context.Context, poolName string) context.Context {
+	return context.WithValue(ctx, workerPoolNameKey{}, poolName)
+}
+
+// workerPoolNameFromContext returns the name of the worker pool stored in the given context, if any.
+func workerPoolNameFromContext(ctx context.Context) (string, bool) {
+	poolName, ok := ctx.Value(workerPoolNameKey{}).(string)
+	return poolName, ok && poolName != ""
+}
+
+// workerPoolFromCluster returns the worker pool of the Shoot of the given cluster whose OperatingSystemConfig is
+// mutated, if any.
+func workerPoolFromCluster(ctx context.Context, cluster *extensionscontroller.Cluster) *gardencorev1beta1.Worker {
+	poolName, ok := workerPoolNameFromContext(ctx)
+	if !ok || cluster == nil || cluster.Shoot == nil {
+		return nil
+	}
+	for i := range cluster.Shoot.Spec.Provider.Workers {
+		if cluster.Shoot.Spec.Provider.Workers[i].Name == poolName {
+			return &cluster.Shoot.Spec.Provider.Workers[i]
+		}
+	}
+	return nil
+}
+
+// workerPoolMutator passes the worker pool of an OperatingSystemConfig to the ensurer, which is otherwise only
+// given the kubelet configuration and unit options of the pool.
+type workerPoolMutator struct {
+	extensionswebhook.Mutator
+}
+
+// Mutate stores the worker pool of OperatingSystemConfigs in the context and delegates to the wrapped mutator.
+func (m *workerPoolMutator) Mutate(ctx context.Context, new, old client.Object) error {
+	if osc, ok := new.(*extensionsv1alpha1.OperatingSystemConfig); ok {
+		if poolName, ok := osc.Labels[v1beta1constants.LabelWorkerPool]; ok {
+			ctx = WithWorkerPoolName(ctx, poolName)
+		}
+	}
+	return m.Mutator.Mutate(ctx, new, old)
+}
@@ -0,0 +1,131 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	extensionscontroller "github.com/gardener/gardener/extensions/pkg/controller"
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+
+	metalapi "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+)
+
+const (
+	timesyncdUnitName       = "systemd-timesyncd.service"
+	timesyncdConfigFilePath = "/etc/systemd/timesyncd.conf.d/metal.conf"
+	resolvedUnitName        = "systemd-resolved.service"
+	resolvedConfigFilePath  = "/etc/systemd/resolved.conf.d/metal.conf"
+	lldpdUnitName           = "lldpd.service"
+	// updateCACertsUnitName is the unit deployed by Gardener which updates the trusted certificates of the node.
+	updateCACertsUnitName = "updatecacerts.service"
+)
+
+// machineImagesWithoutLLDPD are the machine images which do not ship lldpd, so that lldpd.service is not enabled on
+// their nodes.
+var machineImagesWithoutLLDPD = sets.New("flatcar")
+
+// caBundleFilePaths are the paths the CA bundle of the region is written to. The first one is picked up by Debian
+// based operating systems, the second one by Flatcar and the last one by Redhat and SUSE.
+var caBundleFilePaths = []string{
+	"/var/lib/ca-certificates-local/metal-region.crt",
+	"/etc/ssl/certs/metal-region.pem",
+	"/etc/pki/trust/anchors/metal-region.pem",
+}
+
+// regionNodeConfigFromCluster returns the node settings of the region of the given cluster, if any.
+func regionNodeConfigFromCluster(cluster *extensionscontroller.Cluster) (*metalapi.RegionNodeConfig, error) {
+	if cluster == nil || cluster.Shoot == nil {
+		return nil, nil
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return nil, err
+	}
+	if cloudProfileConfig == nil {
+		return nil, nil
+	}
+
+	for _, region := range cloudProfileConfig.RegionConfigs {
+		if region.Name == cluster.Shoot.Spec.Region {
+			return region.Node, nil
+		}
+	}
+	return nil, nil
+}
+
+// ensureRegionNodeFiles ensures the configuration files for the node settings of the region.
+func ensureRegionNodeFiles(files []extensionsv1alpha1.File, node *metalapi.RegionNodeConfig) []extensionsv1alpha1.File {
+	if len(node.NTPServers) > 0 {
+		files = extensionswebhook.EnsureFileWithPath(files, configFile(timesyncdConfigFilePath,
+			fmt.Sprintf("[Time]\nNTP=%s\n", strings.Join(node.NTPServers, " "))))
+	}
+	if len(node.DNSServers) > 0 {
+		files = extensionswebhook.EnsureFileWithPath(files, configFile(resolvedConfigFilePath,
+			fmt.Sprintf("[Resolve]\nDNS=%s\n", strings.Join(node.DNSServers, " "))))
+	}
+	if len(node.CABundle) > 0 {
+		for _, path := range caBundleFilePaths {
+			files = extensionswebhook.EnsureFileWithPath(files, configFile(path, node.CABundle))
+		}
+	}
+	return files
+}
+
+// ensureRegionNodeUnits ensures the units for the node settings of the region on nodes with the given machine image.
+// Units whose configuration files are managed by the extension are restarted whenever the files change.
+func ensureRegionNodeUnits(units []extensionsv1alpha1.Unit, node *metalapi.RegionNodeConfig, machineImage string) []extensionsv1alpha1.Unit {
+	if len(node.NTPServers) > 0 {
+		units = extensionswebhook.EnsureUnitWithName(units, extensionsv1alpha1.Unit{
+			Name:      timesyncdUnitName,
+			Command:   ptr.To(extensionsv1alpha1.CommandRestart),
+			Enable:    ptr.To(true),
+			FilePaths: []string{timesyncdConfigFilePath},
+		})
+	}
+	if len(node.DNSServers) > 0 {
+		units = extensionswebhook.EnsureUnitWithName(units, extensionsv1alpha1.Unit{
+			Name:      resolvedUnitName,
+			Command:   ptr.To(extensionsv1alpha1.CommandRestart),
+			Enable:    ptr.To(true),
+			FilePaths: []string{resolvedConfigFilePath},
+		})
+	}
+	if node.LLDP && !machineImagesWithoutLLDPD.Has(machineImage) {
+		units = extensionswebhook.EnsureUnitWithName(units, extensionsv1alpha1.Unit{
+			Name:    lldpdUnitName,
+			Command: ptr.To(extensionsv1alpha1.CommandStart),
+			Enable:  ptr.To(true),
+		})
+	}
+	if len(node.CABundle) > 0 {
+		if u := extensionswebhook.UnitWithName(units, updateCACertsUnitName); u != nil {
+			for _, path := range caBundleFilePaths {
+				if !slices.Contains(u.FilePaths, path) {
+					u.FilePaths = append(u.FilePaths, path)
+				}
+			}
+		}
+	}
+	return units
+}
+
+func configFile(path, content string) extensionsv1alpha1.File {
+	return extensionsv1alpha1.File{
+		Path:        path,
+		Permissions: ptr.To[uint32](0644),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Data: content,
+			},
+		},
+	}
+}
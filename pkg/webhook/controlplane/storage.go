@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2024 SAP SE or an SAP affiliate company and IronCore contributors
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	extensionswebhook "github.com/gardener/gardener/extensions/pkg/webhook"
+	extensionscontextwebhook "github.com/gardener/gardener/extensions/pkg/webhook/context"
+	extensionsv1alpha1 "github.com/gardener/gardener/pkg/apis/extensions/v1alpha1"
+	"k8s.io/utils/ptr"
+
+	metalapi "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/apis/metal/helper"
+	"github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal"
+	metalhelper "github.com/ironcore-dev/gardener-extension-provider-ironcore-metal/pkg/metal/helper"
+)
+
+const (
+	localVolumesUnitName   = "metal-local-volumes.service"
+	localVolumesScriptPath = "/opt/bin/metal-local-volumes.sh"
+)
+
+// localVolumesScript exposes the unused disks of the machine in the directory the local static volume provisioner
+// discovers the volumes of the given volume type in. Disks which are partitioned, formatted or mounted, e.g. the disk
+// of the operating system, are skipped. The disks are linked by their stable identifiers, so that the links survive
+// reboots, and are formatted by the kubelet when the first volume is mounted.
+var localVolumesScript = `#!/bin/bash
+set -o errexit
+set -o nounset
+set -o pipefail
+
+host_dir="` + metal.LocalVolumeHostDirPrefix + `/$1"
+mkdir -p "${host_dir}"
+
+for device in $(lsblk --nodeps --noheadings --paths --raw --output NAME,TYPE | awk '$2 == "disk" { print $1 }'); do
+  if [[ "$(lsblk --noheadings --raw --output NAME "${device}" | wc -l)" -gt 1 ]] ||
+    [[ -n "$(lsblk --nodeps --noheadings --raw --output FSTYPE,MOUNTPOINT "${device}" | tr -d '[:space:]')" ]]; then
+    continue
+  fi
+
+  source="${device}"
+  for link in /dev/disk/by-id/wwn-* /dev/disk/by-id/nvme-eui.* /dev/disk/by-id/*; do
+    if [[ -L "${link}" && "$(readlink -f "${link}")" == "${device}" ]]; then
+      source="${link}"
+      break
+    fi
+  done
+
+  target="${host_dir}/$(basename "${source}")"
+  if [[ ! -e "${target}" ]]; then
+    echo "Exposing ${device} as local volume ${target}"
+    ln -s "${source}" "${target}"
+  fi
+done
+`
+
+// localVolumesUnitContent returns the content of the unit preparing the local volumes of the given volume type.
+func localVolumesUnitContent(volumeType string) string {
+	return `[Unit]
+Description=Expose the unused disks of the machine as local volumes
+Before=kubelet.service
+[Service]
+Type=oneshot
+RemainAfterExit=yes
+ExecStart=` + localVolumesScriptPath + ` ` + volumeType + `
+[Install]
+WantedBy=multi-user.target
+`
+}
+
+// localVolumeType returns the volume type of the worker pool whose OperatingSystemConfig is mutated, if the Shoot
+// deploys a StorageClass of that type. The disks of such pools are exposed as local static volumes.
+func (e *ensurer) localVolumeType(ctx context.Context, gctx extensionscontextwebhook.GardenContext) (string, error) {
+	cluster, err := gctx.GetCluster(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster: %w", err)
+	}
+	worker := workerPoolFromCluster(ctx, cluster)
+	if worker == nil || worker.Volume == nil || worker.Volume.Type == nil {
+		return "", nil
+	}
+
+	cpConfig, err := e.controlPlaneConfig(cluster)
+	if err != nil {
+		return "", err
+	}
+	if cpConfig.Storage == nil {
+		return "", nil
+	}
+
+	cloudProfileConfig, err := helper.CloudProfileConfigFromCluster(cluster)
+	if err != nil {
+		return "", err
+	}
+	if cloudProfileConfig == nil {
+		return "", nil
+	}
+
+	storageClasses, _, err := metalhelper.SelectStorageClasses(cpConfig.Storage, cloudProfileConfig.StorageClasses)
+	if err != nil {
+		return "", err
+	}
+	if !slices.ContainsFunc(storageClasses, func(sc metalapi.StorageClass) bool { return sc.Type == *worker.Volume.Type }) {
+		return "", nil
+	}
+	return *worker.Volume.Type, nil
+}
+
+// ensureLocalVolumesUnit ensures the unit preparing the local volumes of the given volume type.
+func ensureLocalVolumesUnit(units []extensionsv1alpha1.Unit, volumeType string) []extensionsv1alpha1.Unit {
+	return extensionswebhook.EnsureUnitWithName(units, extensionsv1alpha1.Unit{
+		Name:      localVolumesUnitName,
+		Command:   ptr.To(extensionsv1alpha1.CommandStart),
+		Enable:    ptr.To(true),
+		Content:   ptr.To(localVolumesUnitContent(volumeType)),
+		FilePaths: []string{localVolumesScriptPath},
+	})
+}
+
+// ensureLocalVolumesFiles ensures the script preparing the local volumes.
+func ensureLocalVolumesFiles(files []extensionsv1alpha1.File) []extensionsv1alpha1.File {
+	return extensionswebhook.EnsureFileWithPath(files, extensionsv1alpha1.File{
+		Path:        localVolumesScriptPath,
+		Permissions: ptr.To[uint32](0755),
+		Content: extensionsv1alpha1.FileContent{
+			Inline: &extensionsv1alpha1.FileContentInline{
+				Data: localVolumesScript,
+			},
+		},
+	})
+}